@@ -2,4 +2,4 @@ module cos316.princeton.edu/assignment4
 
 go 1.17
 
-require github.com/mattn/go-sqlite3 v1.14.10
+require github.com/mattn/go-sqlite3 v1.14.16
@@ -0,0 +1,131 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+)
+
+/*
+	Association gives access to the rows related to one model through a
+	many2many relationship (see the comment above relation for more info).
+	Use DB.Association to get one.
+
+	Every method changes both the join table and the relationship field of
+	the model, so the field stays in sync with the database.
+*/
+type Association struct {
+	db    *DB
+	model reflect.Value
+	rel   relation
+	key   modelField
+}
+
+/*
+	Association returns the Association for the many2many relationship
+	field called name on model, which must be a pointer to a model that
+	has already been created.
+
+	Association panics if name is not a many2many relationship field.
+
+	Example usage to enroll a user in two courses:
+	db.Association(&user, "Courses").Append(&cos316, &cos326)
+*/
+func (db *DB) Association(model interface{}, name string) *Association {
+	v := reflect.ValueOf(model).Elem()
+	rel, ok := db.modelRelation(v.Type(), name)
+	if !ok || rel.kind != many2many {
+		log.Panicf("Many2many relationship %v not found on %v!", name, v.Type().Name())
+	}
+	key := requirePrimaryKey(db.modelFields(v.Type()), v.Type())
+	return &Association{db: db, model: v, rel: rel, key: key}
+}
+
+/*
+	Append relates each of values to the model. values are pointers to
+	models of the related type; those that have not been created yet (whose
+	primary key is zero) are created first. Relating an already related
+	model has no effect.
+*/
+func (a *Association) Append(values ...interface{}) {
+	query := fmt.Sprintf("INSERT OR IGNORE INTO %v(%v, %v) VALUES(?, ?)", a.rel.joinTable, a.rel.joinForeignKey, a.rel.joinReferences)
+	field := a.model.FieldByIndex(a.rel.index)
+	for _, value := range values {
+		target := a.targetValue(value)
+		target_key := target.FieldByIndex(a.targetKey().index)
+		if target_key.IsZero() {
+			a.db.Create(target.Addr().Interface())
+		}
+
+		if _, err := a.db.inner.Exec(query, a.ownerKey(), target_key.Interface()); err != nil {
+			log.Panic(err)
+		}
+		if a.indexOf(target_key.Interface()) == -1 {
+			field.Set(reflect.Append(field, addressOrValue(target, field.Type().Elem())))
+		}
+	}
+}
+
+// Remove unrelates each of values from the model, without deleting them.
+func (a *Association) Remove(values ...interface{}) {
+	query := fmt.Sprintf("DELETE FROM %v WHERE %v = ? AND %v = ?", a.rel.joinTable, a.rel.joinForeignKey, a.rel.joinReferences)
+	field := a.model.FieldByIndex(a.rel.index)
+	for _, value := range values {
+		target_key := a.targetValue(value).FieldByIndex(a.targetKey().index).Interface()
+		if _, err := a.db.inner.Exec(query, a.ownerKey(), target_key); err != nil {
+			log.Panic(err)
+		}
+		if i := a.indexOf(target_key); i != -1 {
+			field.Set(reflect.AppendSlice(field.Slice(0, i), field.Slice(i+1, field.Len())))
+		}
+	}
+}
+
+// Replace relates the model to exactly the given values (see Append).
+func (a *Association) Replace(values ...interface{}) {
+	a.Clear()
+	a.Append(values...)
+}
+
+// Clear unrelates every related model from the model, without deleting them.
+func (a *Association) Clear() {
+	query := fmt.Sprintf("DELETE FROM %v WHERE %v = ?", a.rel.joinTable, a.rel.joinForeignKey)
+	if _, err := a.db.inner.Exec(query, a.ownerKey()); err != nil {
+		log.Panic(err)
+	}
+	field := a.model.FieldByIndex(a.rel.index)
+	field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+}
+
+// Returns the primary key of the model
+func (a *Association) ownerKey() interface{} {
+	return a.model.FieldByIndex(a.key.index).Interface()
+}
+
+// Returns the primary key field of the related model
+func (a *Association) targetKey() modelField {
+	return requirePrimaryKey(a.db.modelFields(a.rel.target), a.rel.target)
+}
+
+// Returns the related struct a value passed to Append or Remove points to,
+// panicking if it is not a pointer to the related model type
+func (a *Association) targetValue(value interface{}) reflect.Value {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Ptr || v.Elem().Type() != a.rel.target {
+		log.Panicf("Value for %v must be a *%v, not %T!", a.rel.name, a.rel.target.Name(), value)
+	}
+	return v.Elem()
+}
+
+// Returns the position of the related model with the given key in the
+// model's relationship field, or -1 if it is not there
+func (a *Association) indexOf(key interface{}) int {
+	field := a.model.FieldByIndex(a.rel.index)
+	for i := 0; i < field.Len(); i++ {
+		related := reflect.Indirect(field.Index(i))
+		if fmt.Sprint(related.FieldByIndex(a.targetKey().index).Interface()) == fmt.Sprint(key) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,154 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Member Table Schema
+type Member struct {
+	ID       int64 `dorm:"primary_key"`
+	FullName string
+	Courses  []Course `dorm:"many2many:member_courses"`
+}
+
+// Course Table Schema
+type Course struct {
+	ID    int64 `dorm:"primary_key"`
+	Title string
+}
+
+/*
+	Helper method to test that a member's Courses field holds the expected course titles, in order.
+*/
+func helperTestCourses(t *testing.T, member Member, expected []string) {
+	titles := []string{}
+	for _, course := range member.Courses {
+		titles = append(titles, course.Title)
+	}
+	if fmt.Sprint(titles) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v to have courses %v but instead found %v", member.FullName, expected, titles)
+	}
+}
+
+/*
+	Helper method to test that each member found with their courses preloaded
+	has the expected course titles, in order.
+*/
+func helperTestPreloadedCourses(t *testing.T, db DB, expected map[string][]string) {
+	members := []Member{}
+	db.Find(&members, FindArgs{}.Preload("Courses"))
+	if len(members) != len(expected) {
+		t.Errorf("Expected %v members but instead found %v members", len(expected), len(members))
+	}
+	for _, member := range members {
+		helperTestCourses(t, member, expected[member.FullName])
+	}
+}
+
+func TestAutoMigrate(t *testing.T) {
+	fmt.Println(">>> AUTO MIGRATE TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+
+	db.AutoMigrate(&Member{}, &Course{}, &Contact{})
+
+	fmt.Println("Test: Tables Created")
+	for table, columns := range map[string][]string{
+		"member":         {"id", "full_name"},
+		"course":         {"id", "title"},
+		"member_courses": {"member_id", "course_id"},
+		"contact":        {"id", "created_by", "updated_by", "full_name", "addr_street", "addr_city"},
+	} {
+		if found := db.tableColumns(table); fmt.Sprint(found) != fmt.Sprint(columns) {
+			t.Errorf("Expected table %v to have columns %v but instead found %v", table, columns, found)
+		}
+	}
+
+	fmt.Println("Test: New Column Added")
+	_, err := conn.Exec(`create table user (full_name text)`)
+	if err != nil {
+		panic(err)
+	}
+	db.AutoMigrate(&User{}, &Member{})
+	columns := []string{"full_name", "age", "class_year", "is_enrolled"}
+	if found := db.tableColumns("user"); fmt.Sprint(found) != fmt.Sprint(columns) {
+		t.Errorf("Expected table user to have columns %v but instead found %v", columns, found)
+	}
+}
+
+func TestMany2Many(t *testing.T) {
+	fmt.Println(">>> MANY2MANY TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Member{}, &Course{})
+
+	member_nick := Member{FullName: "Nick"}
+	member_shannon := Member{FullName: "Shannon"}
+	db.Create(&member_nick)
+	db.Create(&member_shannon)
+
+	course_316 := Course{Title: "COS 316"}
+	course_326 := Course{Title: "COS 326"}
+	db.Create(&course_316)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Append Creates New Courses")
+	db.Association(&member_nick, "Courses").Append(&course_316, &course_326)
+	helperTestCourses(t, member_nick, []string{"COS 316", "COS 326"})
+	if course_326.ID == 0 {
+		t.Errorf("Expected COS 326 to be created but it has no ID")
+	}
+	db.Association(&member_shannon, "Courses").Append(&course_326)
+	helperTestPreloadedCourses(t, db, map[string][]string{
+		"Nick":    {"COS 316", "COS 326"},
+		"Shannon": {"COS 326"},
+	})
+
+	fmt.Println("Test: Append Twice")
+	db.Association(&member_nick, "Courses").Append(&course_316)
+	helperTestCourses(t, member_nick, []string{"COS 316", "COS 326"})
+	helperTestPreloadedCourses(t, db, map[string][]string{
+		"Nick":    {"COS 316", "COS 326"},
+		"Shannon": {"COS 326"},
+	})
+
+	fmt.Println("Test: Remove")
+	db.Association(&member_nick, "Courses").Remove(&course_316)
+	helperTestCourses(t, member_nick, []string{"COS 326"})
+	helperTestPreloadedCourses(t, db, map[string][]string{
+		"Nick":    {"COS 326"},
+		"Shannon": {"COS 326"},
+	})
+
+	fmt.Println("Test: Replace")
+	db.Association(&member_shannon, "Courses").Replace(&course_316)
+	helperTestCourses(t, member_shannon, []string{"COS 316"})
+	helperTestPreloadedCourses(t, db, map[string][]string{
+		"Nick":    {"COS 326"},
+		"Shannon": {"COS 316"},
+	})
+
+	fmt.Println("Test: Clear")
+	db.Association(&member_nick, "Courses").Clear()
+	helperTestCourses(t, member_nick, []string{})
+	helperTestPreloadedCourses(t, db, map[string][]string{
+		"Nick":    {},
+		"Shannon": {"COS 316"},
+	})
+
+	fmt.Println("Test: Courses Not Deleted")
+	courses := []Course{}
+	db.Find(&courses, FindArgs{})
+	if len(courses) != 2 {
+		t.Errorf("Expected 2 courses but instead found %v", len(courses))
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Not a Many2many Relationship")
+		db.Association(&member_nick, "FullName")
+	})
+}
@@ -0,0 +1,108 @@
+package sdorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+/*
+	Converts value, a new value for a field of type t in Update, to type t,
+	returning false if the types are genuinely incompatible. Accepted are:
+	- values of type t itself
+	- nil, and driver.Valuer values whose driver value is nil (NULL), for
+	  fields that can hold NULL: pointers, slices, maps, and types that
+	  scan NULL (sql.Scanner) or store their zero value as NULL (driver.Valuer)
+	- numbers of any numeric type, if t holds them exactly (e.g. int64(3)
+	  for an int field, but not 3.5 or -1 for a uint field)
+	- values of another type with the same basic kind, such as string for
+	  a field of type `type Status string`, or the other way around
+	- other driver.Valuer values, whose driver value is converted in turn
+	- values a field type implementing sql.Scanner can scan, e.g. a string
+	  for a sql.NullString field
+
+	Example:
+	coerceValue(int64(3), reflect.TypeOf(0)) ==> reflect.ValueOf(3), true
+	coerceValue(0, reflect.TypeOf("")) ==> false
+*/
+func coerceValue(value interface{}, t reflect.Type) (reflect.Value, bool) {
+	if value == nil {
+		return nullValue(t)
+	}
+
+	v := reflect.ValueOf(value)
+	switch {
+	case v.Type() == t:
+		return v, true
+	case isNumberKind(v.Kind()) && isNumberKind(t.Kind()):
+		converted := v.Convert(t)
+		// lossless only if converting back gives the same number, of the same sign
+		if converted.Convert(v.Type()).Interface() != value || isNegative(v) != isNegative(converted) {
+			return reflect.Value{}, false
+		}
+		return converted, true
+	case v.Kind() == t.Kind() && v.Type().ConvertibleTo(t):
+		return v.Convert(t), true
+	}
+
+	if valuer, ok := value.(driver.Valuer); ok {
+		driver_value, err := valuer.Value()
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		if driver_value == nil {
+			return nullValue(t)
+		}
+		return coerceValue(driver_value, t)
+	}
+
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) {
+		driver_value, err := driver.DefaultParameterConverter.ConvertValue(value)
+		if err != nil {
+			return reflect.Value{}, false
+		}
+		scanned := reflect.New(t)
+		if err := scanned.Interface().(sql.Scanner).Scan(driver_value); err != nil {
+			return reflect.Value{}, false
+		}
+		return scanned.Elem(), true
+	}
+	return reflect.Value{}, false
+}
+
+// Returns the value of type t standing for NULL, or false if t cannot hold NULL
+func nullValue(t reflect.Type) (reflect.Value, bool) {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return reflect.Zero(t), true
+	}
+	if reflect.PtrTo(t).Implements(reflect.TypeOf((*sql.Scanner)(nil)).Elem()) {
+		scanned := reflect.New(t)
+		if err := scanned.Interface().(sql.Scanner).Scan(nil); err != nil {
+			return reflect.Value{}, false
+		}
+		return scanned.Elem(), true
+	}
+	if valuer, ok := reflect.Zero(t).Interface().(driver.Valuer); ok {
+		if driver_value, err := valuer.Value(); err == nil && driver_value == nil {
+			return reflect.Zero(t), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// Checks if k is an integer or floating point kind
+func isNumberKind(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Float64 && k != reflect.Uintptr
+}
+
+// Checks if the number v is negative
+func isNegative(v reflect.Value) bool {
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return v.Int() < 0
+	case v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64:
+		return v.Float() < 0
+	}
+	return false
+}
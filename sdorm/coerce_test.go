@@ -0,0 +1,118 @@
+package sdorm
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type TicketStatus string
+
+// Model with fields of named, small and Scanner types
+type Ticket struct {
+	ID       int64 `dorm:"primary_key"`
+	Status   TicketStatus
+	Priority uint8
+	Score    float64
+	Note     sql.NullString
+}
+
+func TestCoerceValue(t *testing.T) {
+	fmt.Println(">>> COERCE VALUE TESTS <<<")
+	tests := []struct {
+		value    interface{}
+		t        reflect.Type
+		expected interface{}
+		ok       bool
+	}{
+		{3, reflect.TypeOf(0), 3, true},
+		{int64(3), reflect.TypeOf(0), 3, true},
+		{int8(-3), reflect.TypeOf(int64(0)), int64(-3), true},
+		{3, reflect.TypeOf(0.0), 3.0, true},
+		{3.0, reflect.TypeOf(0), 3, true},
+		{3.5, reflect.TypeOf(0), nil, false},
+		{-1, reflect.TypeOf(uint(0)), nil, false},
+		{300, reflect.TypeOf(uint8(0)), nil, false},
+		{"open", reflect.TypeOf(TicketStatus("")), TicketStatus("open"), true},
+		{TicketStatus("open"), reflect.TypeOf(""), "open", true},
+		{0, reflect.TypeOf(""), nil, false},
+		{"3", reflect.TypeOf(0), nil, false},
+		{true, reflect.TypeOf(0), nil, false},
+		{nil, reflect.TypeOf(""), nil, false},
+		{nil, reflect.TypeOf([]string{}), []string(nil), true},
+		{sql.NullInt64{Int64: 7, Valid: true}, reflect.TypeOf(0), 7, true},
+		{sql.NullString{String: "x", Valid: true}, reflect.TypeOf(0), nil, false},
+		{sql.NullInt64{}, reflect.TypeOf(0), nil, false},
+		{sql.NullInt64{}, reflect.TypeOf(new(int)), (*int)(nil), true},
+		{sql.NullInt64{}, reflect.TypeOf(sql.NullString{}), sql.NullString{}, true},
+		{nil, reflect.TypeOf(sql.NullString{}), sql.NullString{}, true},
+		{"note", reflect.TypeOf(sql.NullString{}), sql.NullString{String: "note", Valid: true}, true},
+	}
+	for _, test := range tests {
+		value, ok := coerceValue(test.value, test.t)
+		if ok != test.ok || ok && !reflect.DeepEqual(value.Interface(), test.expected) {
+			found := interface{}(nil)
+			if ok {
+				found = value.Interface()
+			}
+			t.Errorf("Expected %#v as %v to give %#v (%v) but instead found %#v (%v)", test.value, test.t, test.expected, test.ok, found, ok)
+		}
+	}
+}
+
+func TestUpdateCoercion(t *testing.T) {
+	fmt.Println(">>> UPDATE COERCION TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Ticket{})
+	db.Create(&Ticket{Status: "new", Priority: 1})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Convertible Values")
+	updates := make(Updates)
+	addUpdate(updates, "Status", "open")
+	addUpdate(updates, "Priority", int64(5))
+	addUpdate(updates, "Score", 4)
+	addUpdate(updates, "Note", "urgent")
+	rows_updated := db.AllowGlobal().Update(&Ticket{}, DeleteOrUpdateArgs{}, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+	ticket := Ticket{}
+	db.First(&ticket, FindArgs{})
+	expected := Ticket{ID: 1, Status: "open", Priority: 5, Score: 4, Note: sql.NullString{String: "urgent", Valid: true}}
+	if ticket != expected {
+		t.Errorf("Expected %+v but instead found %+v", expected, ticket)
+	}
+
+	fmt.Println("Test: Null Through Valuer")
+	updates = make(Updates)
+	addUpdate(updates, "Note", sql.NullString{})
+	db.AllowGlobal().Update(&Ticket{}, DeleteOrUpdateArgs{}, updates)
+	db.First(&ticket, FindArgs{})
+	if ticket.Note.Valid {
+		t.Errorf("Expected a NULL note but instead found %+v", ticket.Note)
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Lossy Number")
+		updates = make(Updates)
+		addUpdate(updates, "Priority", 256)
+		db.AllowGlobal().Update(&Ticket{}, DeleteOrUpdateArgs{}, updates)
+	})
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Incompatible Kind")
+		updates = make(Updates)
+		addUpdate(updates, "Status", 1)
+		db.AllowGlobal().Update(&Ticket{}, DeleteOrUpdateArgs{}, updates)
+	})
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: NULL for Non-Nullable Field")
+		updates = make(Updates)
+		addUpdate(updates, "Priority", sql.NullInt64{})
+		db.AllowGlobal().Update(&Ticket{}, DeleteOrUpdateArgs{}, updates)
+	})
+}
@@ -0,0 +1,21 @@
+package sdorm
+
+/*
+	Count returns the number of rows Find would find for model with args,
+	skipping soft-deleted rows in the same way. Preloads in args are
+	ignored.
+
+	Count returns an error if the query fails. Like Find, it panics if
+	args are invalid or the table does not exist.
+
+	Example usage:
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	seniors, err := db.Count(&User{}, FindArgs{andFilter: filter})
+*/
+func (db *DB) Count(model interface{}, args FindArgs) (int, error) {
+	rows := db.SubQuery(model, args)
+	count := 0
+	err := db.Raw(&count, "SELECT COUNT(*) FROM ("+rows.query+")", rows.args...)
+	return count, err
+}
@@ -0,0 +1,15 @@
+package sdorm
+
+import "errors"
+
+// Returned by First, Last and Take when no row matches
+var ErrRecordNotFound = errors.New("sdorm: record not found")
+
+// Returned by Save, and panicked by Update, when a model with a
+// `dorm:"version"` field is written at a version its row has moved past,
+// because another writer changed the row since the model was read
+var ErrStaleObject = errors.New("sdorm: stale object, row was changed since it was read")
+
+// Panicked by Update, Delete and Restore when they have no filter and
+// would write every row of the table, unless the DB allows it (see AllowGlobal)
+var ErrMissingWhereClause = errors.New("sdorm: missing WHERE clause, writing every row requires AllowGlobal")
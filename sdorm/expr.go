@@ -0,0 +1,63 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+)
+
+/*
+	Type of a SQL expression used as a new value in Updates, computed by
+	the database from the row being updated rather than given literally,
+	so that e.g. an increment is atomic. Build one with Expr, Increment
+	or Decrement.
+*/
+type Expression struct {
+	sql      string
+	args     []interface{}
+	relative bool
+}
+
+/*
+	Expr returns an Expression for the SQL in sql, which refers to columns
+	by their name in the table and may use "?" placeholders, bound in
+	order to args. The new value is not converted or validated.
+
+	Example usage:
+	updates := make(Updates)
+	addUpdate(updates, "Age", Expr("age * 2 + ?", 1))
+	db.Update(&User{}, args, updates)
+	==> UPDATE user SET age=(age * 2 + ?) ... with 1 bound to the placeholder
+*/
+func Expr(sql string, args ...interface{}) Expression {
+	return Expression{sql: sql, args: args}
+}
+
+/*
+	Increment returns an Expression adding n to the number in the field
+	it is the new value of.
+
+	Example usage:
+	addUpdate(updates, "Age", Increment(1))
+	==> UPDATE user SET age=age + ? ... with 1 bound to the placeholder
+*/
+func Increment(n interface{}) Expression {
+	return Expression{sql: " + ?", args: []interface{}{n}, relative: true}
+}
+
+// Decrement returns an Expression subtracting n from the number in the
+// field it is the new value of (see Increment)
+func Decrement(n interface{}) Expression {
+	return Expression{sql: " - ?", args: []interface{}{n}, relative: true}
+}
+
+// Returns the SET clause giving the column of field the value of expr,
+// along with the values bound to its placeholders
+func (expr Expression) setClause(field modelField) (string, []interface{}) {
+	if !expr.relative {
+		return fmt.Sprintf("%v=(%v)", field.column, expr.sql), expr.args
+	}
+	if !isNumberKind(field.field.Type.Kind()) {
+		log.Panicf("Cannot increment or decrement field %v of type %v!", field.name, field.field.Type)
+	}
+	return fmt.Sprintf("%v=%v%v", field.column, field.column, expr.sql), expr.args
+}
@@ -0,0 +1,55 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestExpressionUpdates(t *testing.T) {
+	fmt.Println(">>> EXPRESSION UPDATE TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Increment Seniors")
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	updates := make(Updates)
+	addUpdate(updates, "Age", Increment(1))
+	rows_updated := db.Update(&User{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+	helperTestIntEquality(t, rows_updated, 2)
+
+	fmt.Println("Test: Decrement With Literal Update")
+	filter = make(Filter)
+	addFilter(filter, "FullName", "eq", "Katie")
+	updates = make(Updates)
+	addUpdate(updates, "Age", Decrement(5))
+	addUpdate(updates, "ClassYear", "Junior")
+	db.Update(&User{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+
+	fmt.Println("Test: Expression With Parameters")
+	filter = make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Freshman")
+	updates = make(Updates)
+	addUpdate(updates, "Age", Expr("age * ? + ?", 2, 1))
+	addUpdate(updates, "FullName", Expr("full_name || ?", "!"))
+	db.Update(&User{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+
+	results := []User{}
+	db.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		{FullName: "Nick!", ClassYear: "Freshman", Age: 21, IsEnrolled: true},
+		{FullName: "Shannon!", ClassYear: "Freshman", Age: 41, IsEnrolled: false},
+		{FullName: "Will", ClassYear: "Senior", Age: 21, IsEnrolled: true},
+		{FullName: "Katie", ClassYear: "Junior", Age: 25, IsEnrolled: false},
+		{FullName: "Albert", ClassYear: "Senior", Age: 41, IsEnrolled: true},
+	})
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Increment Non-Number")
+		updates = make(Updates)
+		addUpdate(updates, "FullName", Increment(1))
+		db.AllowGlobal().Update(&User{}, DeleteOrUpdateArgs{}, updates)
+	})
+}
@@ -0,0 +1,160 @@
+package sdorm
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+	Type of the error panicked by Find, Update, Delete and the other
+	methods taking FindArgs or DeleteOrUpdateArgs (or returned by those
+	that return errors), when a query names a field its model does not
+	have: an Updates key, a Filter field, an OrderBy field or a projection
+	column. Queries are checked before anything is run.
+
+	Suggestion is the closest valid name, or empty if none is close.
+
+	Example:
+	addFilter(filter, "FulName", "eq", "Nick")
+	db.Find(&results, FindArgs{andFilter: filter})
+	==> panics with: sdorm: unknown field "FulName" in filter on user, did you mean "FullName"?
+*/
+type UnknownFieldError struct {
+	Field      string
+	Clause     string
+	Table      string
+	Suggestion string
+}
+
+func (err *UnknownFieldError) Error() string {
+	message := fmt.Sprintf("sdorm: unknown field %q in %v on %v", err.Field, err.Clause, err.Table)
+	if err.Suggestion != "" {
+		message += fmt.Sprintf(", did you mean %q?", err.Suggestion)
+	}
+	return message
+}
+
+// Checks that every field named in filter and orderBy refers to one of
+// fields of the model of table
+func (db *DB) checkQueryFields(table string, fields []modelField, filter Filter, orderBy OrderBy) error {
+	field_names := make([]string, 0, len(filter))
+	for field_name := range filter {
+		field_names = append(field_names, field_name)
+	}
+	sort.Strings(field_names)
+
+	for _, field_name := range field_names {
+		if !comparesColumn(filter[field_name]) {
+			continue
+		}
+		// "Field->path" refers to a path inside the column of Field
+		name := strings.TrimSpace(strings.SplitN(field_name, "->", 2)[0])
+		if err := db.checkFieldName(table, fields, name, "filter"); err != nil {
+			return err
+		}
+	}
+	for _, order := range orderBy {
+		if err := db.checkFieldName(table, fields, order[0], "order"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checks that every key of update is the name of one of fields, and every
+// field named in filter refers to one, in an Update of table
+func (db *DB) checkUpdateFields(table string, fields []modelField, filter Filter, update Updates) error {
+	keys := make([]string, 0, len(update))
+	for key := range update {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.name
+	}
+	for _, key := range keys {
+		if !containsString(names, key) {
+			return &UnknownFieldError{Field: key, Clause: "update", Table: table, Suggestion: closestName(key, names)}
+		}
+	}
+	return db.checkQueryFields(table, fields, filter, nil)
+}
+
+// Checks that name refers to one of fields of the model of table, by
+// field name or column, returning an UnknownFieldError if it does not
+func (db *DB) checkFieldName(table string, fields []modelField, name string, clause string) error {
+	column := db.fieldColumn(fields, name)
+	names := []string{}
+	for _, field := range fields {
+		if field.column == column {
+			return nil
+		}
+		names = append(names, field.name)
+	}
+	// every SQLite table has a rowid, which First and Last order by
+	if column == "rowid" || strings.HasSuffix(column, ".rowid") {
+		return nil
+	}
+	return &UnknownFieldError{Field: name, Clause: clause, Table: table, Suggestion: closestName(name, names)}
+}
+
+// Checks if a field's filter compares its column, rather than only
+// holding "exists" conditions, which do not use the field name
+func comparesColumn(field_filter FilterArg) bool {
+	for operator := range field_filter {
+		if operator != "exists" && operator != "nexists" {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the name in names closest to name, ignoring case, or the empty
+// string if even the closest differs in more than a third of its characters
+func closestName(name string, names []string) string {
+	closest := ""
+	best := len(name)/3 + 1
+	for _, candidate := range names {
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(candidate)); distance < best {
+			closest, best = candidate, distance
+		}
+	}
+	return closest
+}
+
+// Returns the Levenshtein distance between a and b: the fewest single
+// character insertions, deletions and substitutions turning a into b
+func editDistance(a string, b string) int {
+	a_runes, b_runes := []rune(a), []rune(b)
+	previous := make([]int, len(b_runes)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a_runes); i++ {
+		current := make([]int, len(b_runes)+1)
+		current[0] = i
+		for j := 1; j <= len(b_runes); j++ {
+			cost := 1
+			if a_runes[i-1] == b_runes[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b_runes)]
+}
+
+// Returns the smallest of values
+func minInt(values ...int) int {
+	smallest := values[0]
+	for _, value := range values[1:] {
+		if value < smallest {
+			smallest = value
+		}
+	}
+	return smallest
+}
@@ -0,0 +1,94 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Checks that theFunc panics with an UnknownFieldError with the given message
+func helperTestUnknownField(t *testing.T, message string, theFunc func()) {
+	defer func() {
+		r := recover()
+		err, ok := r.(error)
+		var unknown *UnknownFieldError
+		if !ok || !errors.As(err, &unknown) || err.Error() != message {
+			t.Errorf("Expected panic with %q but instead got %v", message, r)
+		}
+	}()
+
+	theFunc()
+}
+
+func TestUnknownFields(t *testing.T) {
+	fmt.Println(">>> UNKNOWN FIELD TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+	results := []User{}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Filter Field")
+	helperTestUnknownField(t, `sdorm: unknown field "FulName" in filter on user, did you mean "FullName"?`, func() {
+		db.Find(&results, FindArgs{andFilter: Filter{"FulName": FilterArg{"eq": "Nick"}}})
+	})
+
+	fmt.Println("Test: Filter Field on JSON Path Without Suggestion")
+	helperTestUnknownField(t, `sdorm: unknown field "Setings" in filter on user`, func() {
+		db.Find(&results, FindArgs{andFilter: Filter{"Setings->theme": FilterArg{"eq": "dark"}}})
+	})
+
+	fmt.Println("Test: Order Field")
+	helperTestUnknownField(t, `sdorm: unknown field "classyear" in order on user, did you mean "ClassYear"?`, func() {
+		db.Find(&results, FindArgs{orderBy: OrderBy{{"classyear", "ASC"}}})
+	})
+
+	fmt.Println("Test: Projection Field")
+	helperTestUnknownField(t, `sdorm: unknown field "Ages" in projection on user, did you mean "Age"?`, func() {
+		db.Find(&results, FindArgs{projection: []interface{}{"Ages", "FullName"}})
+	})
+
+	fmt.Println("Test: Updates Key")
+	helperTestUnknownField(t, `sdorm: unknown field "IsEnroled" in update on user, did you mean "IsEnrolled"?`, func() {
+		db.AllowGlobal().Update(&User{}, DeleteOrUpdateArgs{}, Updates{"IsEnroled": false})
+	})
+
+	fmt.Println("Test: Delete Filter Checked Before Deleting")
+	helperTestUnknownField(t, `sdorm: unknown field "Agee" in filter on user, did you mean "Age"?`, func() {
+		db.Delete(&User{}, DeleteOrUpdateArgs{andFilter: Filter{"Agee": FilterArg{"gt": 0}}})
+	})
+	count, _ := db.Count(&User{}, FindArgs{})
+	helperTestIntEquality(t, count, 5)
+
+	fmt.Println("Test: Columns and Naming Strategy Names Accepted")
+	results = []User{}
+	db.Find(&results, FindArgs{andFilter: Filter{"full_name": FilterArg{"eq": "Nick"}, "classYear": FilterArg{"eq": "Freshman"}}})
+	helperTestIntEquality(t, len(results), 1)
+
+	fmt.Println("Test: FindMaps Returns Error")
+	_, err := db.FindMaps("user", FindArgs{orderBy: OrderBy{{"full_nam", "ASC"}}})
+	var unknown *UnknownFieldError
+	if !errors.As(err, &unknown) || unknown.Suggestion != "full_name" {
+		t.Errorf("Expected UnknownFieldError suggesting full_name but instead got %v", err)
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	fmt.Println(">>> EDIT DISTANCE TESTS <<<")
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"Age", "Age", 0},
+		{"Age", "Agee", 1},
+		{"FulName", "FullName", 1},
+		{"kitten", "sitting", 3},
+		{"", "abc", 3},
+	}
+	for _, test := range tests {
+		if distance := editDistance(test.a, test.b); distance != test.expected {
+			t.Errorf("Expected distance %v between %q and %q but instead found %v", test.expected, test.a, test.b, distance)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package sdorm
+
+import (
+	"log"
+	"reflect"
+	"strings"
+)
+
+/*
+	First finds the first row matching args and stores it in result,
+	which must be a pointer to a model struct. Rows are ordered by
+	args.orderBy, or by the model's primary key if no order is given
+	(by rowid if the model has none). args.limit is ignored.
+
+	First returns ErrRecordNotFound, leaving result unchanged, if no
+	row matches. Like Find, it panics if args are invalid.
+
+	Example usage:
+	user := User{}
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	err := db.First(&user, FindArgs{andFilter: filter})
+	if errors.Is(err, ErrRecordNotFound) {
+		...
+	}
+*/
+func (db *DB) First(result interface{}, args FindArgs) error {
+	if len(args.orderBy) == 0 {
+		args.orderBy = db.primaryKeyOrder(result, args, "ASC")
+	}
+	return db.take(result, args)
+}
+
+/*
+	Last is like First, but finds the last row in the order: rows are
+	sorted by args.orderBy with every direction reversed, or by the
+	model's primary key in descending order if no order is given.
+*/
+func (db *DB) Last(result interface{}, args FindArgs) error {
+	if len(args.orderBy) == 0 {
+		args.orderBy = db.primaryKeyOrder(result, args, "DESC")
+	} else {
+		reversed := make(OrderBy, len(args.orderBy))
+		for i, order := range args.orderBy {
+			direction := "DESC"
+			if strings.ToUpper(order[1]) == "DESC" {
+				direction = "ASC"
+			}
+			reversed[i] = []string{order[0], direction}
+		}
+		args.orderBy = reversed
+	}
+	return db.take(result, args)
+}
+
+/*
+	Take is like First, but finds any one matching row: rows are
+	sorted by args.orderBy if given, and left in no particular order
+	otherwise.
+*/
+func (db *DB) Take(result interface{}, args FindArgs) error {
+	return db.take(result, args)
+}
+
+// Finds the first row matching args and stores it in result (see First)
+func (db *DB) take(result interface{}, args FindArgs) error {
+	dst := reflect.ValueOf(result)
+	if dst.Kind() != reflect.Ptr || dst.Elem().Kind() != reflect.Struct {
+		log.Panicf("Result must be a pointer to a struct, not %T!", result)
+	}
+
+	found := reflect.New(reflect.SliceOf(dst.Type().Elem()))
+	args.limit = 1
+	db.Find(found.Interface(), args)
+	if found.Elem().Len() == 0 {
+		return ErrRecordNotFound
+	}
+	dst.Elem().Set(found.Elem().Index(0))
+	return nil
+}
+
+// Returns the OrderBy sorting the rows read into result by primary key,
+// or by rowid if the model has none, in the given direction
+func (db *DB) primaryKeyOrder(result interface{}, args FindArgs, direction string) OrderBy {
+	base := result
+	if args.from != nil {
+		base = args.from
+	}
+	name := "rowid"
+	if pk, ok := primaryKeyField(db.modelFields(modelType(base))); ok {
+		name = pk.name
+	}
+	// qualify the key so it is not ambiguous between joined tables
+	if len(args.joins) > 0 {
+		name = db.tableName(base) + "." + name
+	}
+	return OrderBy{{name, direction}}
+}
@@ -0,0 +1,103 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestFirstLastTake(t *testing.T) {
+	fmt.Println(">>> FIRST LAST TAKE TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10, IsEnrolled: true}
+	user_shannon := User{FullName: "Shannon", ClassYear: "Freshman", Age: 20, IsEnrolled: false}
+	user_katie := User{FullName: "Katie", ClassYear: "Sophomore", Age: 30, IsEnrolled: false}
+	user_albert := User{FullName: "Albert", ClassYear: "Senior", Age: 40, IsEnrolled: true}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: First and Last Without Primary Key")
+	user := User{}
+	err := db.First(&user, FindArgs{})
+	helperTestEquality(t, []User{user}, []User{user_nick})
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	err = db.Last(&user, FindArgs{})
+	helperTestEquality(t, []User{user}, []User{user_albert})
+
+	fmt.Println("Test: First and Last With OrderBy")
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "IsEnrolled", "ASC")
+	addOrder(orderBy, "Age", "desc")
+	db.First(&user, FindArgs{orderBy: *orderBy})
+	helperTestEquality(t, []User{user}, []User{user_katie})
+	db.Last(&user, FindArgs{orderBy: *orderBy})
+	helperTestEquality(t, []User{user}, []User{user_nick})
+
+	fmt.Println("Test: Take With Filter")
+	filter := make(Filter)
+	addFilter(filter, "FullName", "eq", "Shannon")
+	err = db.Take(&user, FindArgs{andFilter: filter})
+	helperTestEquality(t, []User{user}, []User{user_shannon})
+
+	fmt.Println("Test: Record Not Found")
+	filter = make(Filter)
+	addFilter(filter, "Age", "gt", 100)
+	user = User{FullName: "Unchanged"}
+	for _, find := range []func(interface{}, FindArgs) error{db.First, db.Last, db.Take} {
+		err = find(&user, FindArgs{andFilter: filter})
+		if !errors.Is(err, ErrRecordNotFound) {
+			t.Errorf("Expected ErrRecordNotFound but instead got %v", err)
+		}
+	}
+	helperTestEquality(t, []User{user}, []User{{FullName: "Unchanged"}})
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Result Not a Pointer")
+		db.First(user, FindArgs{})
+	})
+}
+
+func TestFirstByPrimaryKey(t *testing.T) {
+	fmt.Println(">>> FIRST BY PRIMARY KEY TESTS <<<")
+	conn := connectSQL()
+	createAuthorTables(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	author_nick := Author{FullName: "Nick"}
+	author_shannon := Author{FullName: "Shannon"}
+	db.Create(&author_nick)
+	db.Create(&author_shannon)
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Intro to ORMs"})
+	db.Create(&Post{WriterID: int(author_shannon.ID), Title: "Reflection in Go"})
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Preloading with ORMs"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: First and Last Author")
+	author := Author{}
+	db.First(&author, FindArgs{})
+	if author.ID != author_nick.ID || author.FullName != "Nick" {
+		t.Errorf("Expected %+v but instead found %+v", author_nick, author)
+	}
+	db.Last(&author, FindArgs{})
+	if author.ID != author_shannon.ID || author.FullName != "Shannon" {
+		t.Errorf("Expected %+v but instead found %+v", author_shannon, author)
+	}
+
+	fmt.Println("Test: Last Post of Nick With Join")
+	joins := new(Joins)
+	addJoin(joins, "INNER", &Author{}, "post.WriterID", "author.ID")
+	filter := make(Filter)
+	addFilter(filter, "author.FullName", "eq", "Nick")
+	post := Post{}
+	db.Last(&post, FindArgs{joins: *joins, andFilter: filter})
+	if post.Title != "Preloading with ORMs" {
+		t.Errorf("Expected Preloading with ORMs but instead found %+v", post)
+	}
+}
@@ -0,0 +1,31 @@
+package sdorm
+
+/*
+	AllowGlobal returns a copy of db whose Update, Delete and Restore may
+	run without a filter, writing every row of the table. db itself is
+	unchanged. Otherwise, they panic with ErrMissingWhereClause, since a
+	forgotten filter would wipe or rewrite the whole table.
+
+	Example usage:
+	rows_deleted := db.AllowGlobal().Delete(&User{}, DeleteOrUpdateArgs{})
+*/
+func (db *DB) AllowGlobal() *DB {
+	global := *db
+	global.allowGlobal = true
+	return &global
+}
+
+// SetAllowGlobal enables or disables Update, Delete and Restore without a filter
+// for every query db runs, as AllowGlobal does for a single query.
+func (db *DB) SetAllowGlobal(allow bool) {
+	db.allowGlobal = allow
+}
+
+// Returns ErrMissingWhereClause if filter, the filter of an Update, Delete
+// or Restore with db's scopes applied, is empty and db does not allow it
+func (db *DB) checkGlobal(filter Filter) error {
+	if len(filter) == 0 && !db.allowGlobal {
+		return ErrMissingWhereClause
+	}
+	return nil
+}
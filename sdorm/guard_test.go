@@ -0,0 +1,38 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMissingWhereClause(t *testing.T) {
+	fmt.Println(">>> MISSING WHERE CLAUSE TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	updates := make(Updates)
+	addUpdate(updates, "Age", 50)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Unfiltered Update and Delete")
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Update(&User{}, DeleteOrUpdateArgs{}, updates)
+	})
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Delete(&User{}, DeleteOrUpdateArgs{andFilter: make(Filter)})
+	})
+	count, _ := db.Count(&User{}, FindArgs{})
+	helperTestIntEquality(t, count, 5)
+
+	fmt.Println("Test: Scopes Count as Filters")
+	rows_updated := db.Scopes(enrolledScope).Update(&User{}, DeleteOrUpdateArgs{}, updates)
+	helperTestIntEquality(t, rows_updated, 3)
+
+	fmt.Println("Test: AllowGlobal Leaves db Unchanged")
+	rows_deleted := db.AllowGlobal().Delete(&User{}, DeleteOrUpdateArgs{})
+	helperTestIntEquality(t, rows_deleted, 5)
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Delete(&User{}, DeleteOrUpdateArgs{})
+	})
+}
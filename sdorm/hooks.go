@@ -0,0 +1,105 @@
+package sdorm
+
+/*
+	BeforeCreateHook and the interfaces below are lifecycle hooks. A model
+	implementing one of them has the hook called around the matching
+	operation, with the DB running it, which is the transaction's DB
+	inside Transaction:
+	- BeforeCreate and AfterCreate: around Create, and Save of a model
+	  with a zero primary key
+	- BeforeUpdate and AfterUpdate: around Update, called on its model
+	  argument, and Save of a model with a non-zero primary key
+	- BeforeDelete and AfterDelete: around Delete, called on its model
+	  argument, soft deletes and HardDelete
+	- AfterFind: on each struct Find reads, after preloading
+
+	If a before-hook returns an error the operation is aborted: nothing
+	is written, and the error is returned by Save or else panicked. An
+	error from an after-hook is returned or panicked the same way, after
+	the write, which a surrounding Transaction then rolls back.
+
+	Example usage:
+	func (u *Account) BeforeCreate(tx *DB) error {
+		if u.Email == "" {
+			return errors.New("email is required")
+		}
+		return nil
+	}
+*/
+type BeforeCreateHook interface {
+	BeforeCreate(tx *DB) error
+}
+type AfterCreateHook interface {
+	AfterCreate(tx *DB) error
+}
+type BeforeUpdateHook interface {
+	BeforeUpdate(tx *DB) error
+}
+type AfterUpdateHook interface {
+	AfterUpdate(tx *DB) error
+}
+type BeforeDeleteHook interface {
+	BeforeDelete(tx *DB) error
+}
+type AfterDeleteHook interface {
+	AfterDelete(tx *DB) error
+}
+type AfterFindHook interface {
+	AfterFind(tx *DB) error
+}
+
+// Calls the BeforeCreate hook of model, if it has one
+func (db *DB) beforeCreate(model interface{}) error {
+	if hook, ok := model.(BeforeCreateHook); ok {
+		return hook.BeforeCreate(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the AfterCreate hook of model, if it has one
+func (db *DB) afterCreate(model interface{}) error {
+	if hook, ok := model.(AfterCreateHook); ok {
+		return hook.AfterCreate(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the BeforeUpdate hook of model, if it has one
+func (db *DB) beforeUpdate(model interface{}) error {
+	if hook, ok := model.(BeforeUpdateHook); ok {
+		return hook.BeforeUpdate(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the AfterUpdate hook of model, if it has one
+func (db *DB) afterUpdate(model interface{}) error {
+	if hook, ok := model.(AfterUpdateHook); ok {
+		return hook.AfterUpdate(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the BeforeDelete hook of model, if it has one
+func (db *DB) beforeDelete(model interface{}) error {
+	if hook, ok := model.(BeforeDeleteHook); ok {
+		return hook.BeforeDelete(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the AfterDelete hook of model, if it has one
+func (db *DB) afterDelete(model interface{}) error {
+	if hook, ok := model.(AfterDeleteHook); ok {
+		return hook.AfterDelete(db.withoutReturning())
+	}
+	return nil
+}
+
+// Calls the AfterFind hook of model, if it has one
+func (db *DB) afterFind(model interface{}) error {
+	if hook, ok := model.(AfterFindHook); ok {
+		return hook.AfterFind(db.withoutReturning())
+	}
+	return nil
+}
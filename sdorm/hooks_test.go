@@ -0,0 +1,185 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+var errNoEmail = errors.New("email is required")
+var errProtected = errors.New("account is protected")
+
+// Model with every lifecycle hook, recording the hooks called in events
+type Account struct {
+	ID      int64 `dorm:"primary_key"`
+	Email   string
+	display string
+}
+
+// Row written by Account's AfterCreate hook
+type AuditEntry struct {
+	ID      int64 `dorm:"primary_key"`
+	Message string
+}
+
+var events []string
+
+func (a *Account) BeforeCreate(tx *DB) error {
+	if a.Email == "" {
+		return errNoEmail
+	}
+	events = append(events, "BeforeCreate")
+	return nil
+}
+
+func (a *Account) AfterCreate(tx *DB) error {
+	events = append(events, "AfterCreate")
+	tx.Create(&AuditEntry{Message: "created " + a.Email})
+	return nil
+}
+
+func (a *Account) BeforeUpdate(tx *DB) error {
+	events = append(events, "BeforeUpdate")
+	return nil
+}
+
+func (a *Account) AfterUpdate(tx *DB) error {
+	events = append(events, "AfterUpdate")
+	return nil
+}
+
+func (a *Account) BeforeDelete(tx *DB) error {
+	if a.Email == "admin" {
+		return errProtected
+	}
+	events = append(events, "BeforeDelete")
+	return nil
+}
+
+func (a *Account) AfterDelete(tx *DB) error {
+	events = append(events, "AfterDelete")
+	return nil
+}
+
+func (a *Account) AfterFind(tx *DB) error {
+	a.display = strings.ToUpper(a.Email)
+	return nil
+}
+
+func helperTestEvents(t *testing.T, expected []string) {
+	if fmt.Sprint(events) != fmt.Sprint(expected) {
+		t.Errorf("Expected hooks %v but instead found %v", expected, events)
+	}
+	events = nil
+}
+
+func TestHooks(t *testing.T) {
+	fmt.Println(">>> HOOK TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Account{}, &AuditEntry{})
+	events = nil
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Create Hooks")
+	account := Account{Email: "nick@example.com"}
+	db.Create(&account)
+	helperTestEvents(t, []string{"BeforeCreate", "AfterCreate"})
+	count, _ := db.Count(&AuditEntry{}, FindArgs{})
+	helperTestIntEquality(t, count, 1)
+
+	fmt.Println("Test: BeforeCreate Aborts Create")
+	helperTestPanicError(t, errNoEmail, func() {
+		db.Create(&Account{})
+	})
+	if err := db.Save(&Account{}); !errors.Is(err, errNoEmail) {
+		t.Errorf("Expected %v but instead got %v", errNoEmail, err)
+	}
+	count, _ = db.Count(&Account{}, FindArgs{})
+	helperTestIntEquality(t, count, 1)
+
+	fmt.Println("Test: Update and Save Hooks")
+	updates := make(Updates)
+	addUpdate(updates, "Email", "nicholas@example.com")
+	db.AllowGlobal().Update(&Account{}, DeleteOrUpdateArgs{}, updates)
+	account.Email = "nick@example.org"
+	db.Save(&account)
+	helperTestEvents(t, []string{"BeforeUpdate", "AfterUpdate", "BeforeUpdate", "AfterUpdate"})
+
+	fmt.Println("Test: AfterFind Hook")
+	accounts := []Account{}
+	db.Find(&accounts, FindArgs{})
+	if len(accounts) != 1 || accounts[0].display != "NICK@EXAMPLE.ORG" {
+		t.Errorf("Expected display NICK@EXAMPLE.ORG but instead found %+v", accounts)
+	}
+	found := Account{}
+	db.First(&found, FindArgs{})
+	if found.display != "NICK@EXAMPLE.ORG" {
+		t.Errorf("Expected display NICK@EXAMPLE.ORG but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Delete Hooks")
+	helperTestPanicError(t, errProtected, func() {
+		db.AllowGlobal().Delete(&Account{Email: "admin"}, DeleteOrUpdateArgs{})
+	})
+	helperTestEvents(t, nil)
+	rows_deleted := db.AllowGlobal().Delete(&Account{}, DeleteOrUpdateArgs{})
+	helperTestIntEquality(t, rows_deleted, 1)
+	helperTestEvents(t, []string{"BeforeDelete", "AfterDelete"})
+}
+
+func TestTransactionHooks(t *testing.T) {
+	fmt.Println(">>> TRANSACTION HOOK TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Account{}, &AuditEntry{})
+	events = nil
+	errRollback := errors.New("roll back")
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Hook Writes Roll Back With Transaction")
+	err := db.Transaction(func(tx *DB) error {
+		tx.Create(&Account{Email: "will@example.com"})
+		count, _ := tx.Count(&AuditEntry{}, FindArgs{})
+		helperTestIntEquality(t, count, 1)
+		return errRollback
+	})
+	if !errors.Is(err, errRollback) {
+		t.Errorf("Expected %v but instead got %v", errRollback, err)
+	}
+	count, _ := db.Count(&Account{}, FindArgs{})
+	helperTestIntEquality(t, count, 0)
+	count, _ = db.Count(&AuditEntry{}, FindArgs{})
+	helperTestIntEquality(t, count, 0)
+
+	fmt.Println("Test: Aborted Create Rolls Back Transaction")
+	helperTestPanicError(t, errNoEmail, func() {
+		db.Transaction(func(tx *DB) error {
+			tx.Create(&Account{Email: "katie@example.com"})
+			tx.Create(&Account{})
+			return nil
+		})
+	})
+	count, _ = db.Count(&Account{}, FindArgs{})
+	helperTestIntEquality(t, count, 0)
+
+	fmt.Println("Test: Committed Transaction")
+	err = db.Transaction(func(tx *DB) error {
+		tx.Create(&Account{Email: "albert@example.com"})
+		return tx.Transaction(func(inner *DB) error {
+			return inner.Save(&Account{Email: "shannon@example.com"})
+		})
+	})
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	count, _ = db.Count(&Account{}, FindArgs{})
+	helperTestIntEquality(t, count, 2)
+	count, _ = db.Count(&AuditEntry{}, FindArgs{})
+	helperTestIntEquality(t, count, 2)
+}
@@ -0,0 +1,146 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+/*
+	Type to allow clients to join other tables in Find
+
+	Each Join adds one "kind JOIN table ON left = right" clause, where
+	- kind: "INNER" (only rows with a match) or "LEFT" (all rows of the
+	  tables before, with NULLs where there is no match)
+	- model: a model whose table is joined
+	- left, right: the columns that must be equal, qualified by their
+	  table, e.g. "author.ID" or "post.writer_id"
+
+	Columns of any table in the query can be referred to in a Filter,
+	OrderBy or projection by qualifying them the same way, e.g. "post.Title".
+
+	Soft-deleted rows of a joined model (see Unscoped) are left out of the
+	join by a condition in its ON clause, so a LEFT join still keeps the
+	rows before it, with NULLs, unless the query is unscoped.
+
+	See the comment above addJoin for example usage.
+*/
+type Join struct {
+	kind  string
+	model interface{}
+	left  string
+	right string
+}
+type Joins []Join
+
+/*
+	Helper method for clients needing to construct a Joins type
+
+	The rows are scanned into the result struct passed to Find, whose fields
+	are matched to the column of the same name in the first table that has
+	it, or to a qualified column given with a `dorm:"column:table.column"`
+	tag. Set FindArgs.from to the model of the first table when the result
+	struct is not that model.
+
+	Example usage to find the titles of Nick's posts:
+	type AuthorPost struct {
+		FullName string
+		Title    string
+		PostID   int64 `dorm:"column:post.id"`
+	}
+	joins := new(Joins)
+	addJoin(joins, "INNER", &Post{}, "author.ID", "post.WriterID")
+	filter := make(Filter)
+	addFilter(filter, "author.FullName", "eq", "Nick")
+	results := []AuthorPost{}
+	db.Find(&results, FindArgs{from: &Author{}, joins: *joins, andFilter: filter})
+*/
+func addJoin(joins *Joins, kind string, model interface{}, left string, right string) {
+	*joins = append(*joins, Join{kind: kind, model: model, left: left, right: right})
+}
+
+// Builds the JOIN clause for join, resolving its columns with fields
+func (db *DB) joinClause(join Join, fields []modelField) string {
+	kind := strings.ToUpper(join.kind)
+	if kind != "INNER" && kind != "LEFT" {
+		log.Panic("Invalid join kind provided!")
+	}
+	table := db.tableName(join.model)
+	clause := fmt.Sprintf(" %v JOIN %v ON %v = %v", kind, table,
+		db.fieldColumn(fields, join.left), db.fieldColumn(fields, join.right))
+
+	// skip soft-deleted rows of the joined table
+	if deleted_at, ok := softDeleteField(db.modelFields(modelType(join.model))); ok && !db.unscoped {
+		clause += fmt.Sprintf(" AND %v.%v IS NULL", table, deleted_at.column)
+	}
+	return clause
+}
+
+/*
+	Returns the fields of each of models qualified by their table, so that
+	"table.Field" and "table.column" both resolve to the column "table.column"
+	in filters, orderings and join conditions.
+*/
+func (db *DB) qualifiedFields(models ...interface{}) []modelField {
+	qualified := []modelField{}
+	for _, model := range models {
+		table := db.tableName(model)
+		for _, field := range db.modelFields(modelType(model)) {
+			by_name := field
+			by_name.name = table + "." + field.name
+			by_name.column = table + "." + field.column
+			by_column := by_name
+			by_column.name = table + "." + field.column
+			qualified = append(qualified, by_name, by_column)
+		}
+	}
+	return qualified
+}
+
+/*
+	Returns the SELECT list reading fields from tables, given the columns
+	of each table. A field's column is read from the first table that has
+	it, or from the named table if the column is qualified. Queries over
+	several tables qualify every column and alias it back to the field's
+	column so results can be scanned by name.
+
+	Fields whose column is in none of the tables are skipped, or cause a
+	panic in strict mode.
+*/
+func (db *DB) selectList(fields []modelField, tables []string, columns map[string][]string) []string {
+	list := []string{}
+	for _, field := range fields {
+		source := columnSource(field.column, tables, columns)
+		if source == "" {
+			if db.strict {
+				log.Panicf("Column %v for field %v not found in table %v!", field.column, field.name, strings.Join(tables, ", "))
+			}
+			continue
+		}
+		if len(tables) > 1 {
+			source += fmt.Sprintf(" AS %q", field.column)
+		}
+		list = append(list, source)
+	}
+	return list
+}
+
+// Returns the expression selecting column from tables (see selectList),
+// or the empty string if none of the tables has the column
+func columnSource(column string, tables []string, columns map[string][]string) string {
+	if i := strings.Index(column, "."); i != -1 {
+		if containsString(columns[column[:i]], column[i+1:]) {
+			return column
+		}
+		return ""
+	}
+	for _, table := range tables {
+		if containsString(columns[table], column) {
+			if len(tables) == 1 {
+				return column
+			}
+			return table + "." + column
+		}
+	}
+	return ""
+}
@@ -0,0 +1,103 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Composite result of joining the author and post tables
+type AuthorPost struct {
+	FullName string
+	Title    string
+	PostID   int64 `dorm:"column:post.id"`
+}
+
+func TestJoin(t *testing.T) {
+	fmt.Println(">>> JOIN TESTS <<<")
+	conn := connectSQL()
+	createAuthorTables(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	author_nick := Author{FullName: "Nick"}
+	author_shannon := Author{FullName: "Shannon"}
+	author_will := Author{FullName: "Will"}
+	db.Create(&author_nick)
+	db.Create(&author_shannon)
+	db.Create(&author_will)
+
+	post_orms := Post{WriterID: int(author_nick.ID), Title: "Intro to ORMs"}
+	post_reflection := Post{WriterID: int(author_shannon.ID), Title: "Reflection in Go"}
+	post_preloading := Post{WriterID: int(author_nick.ID), Title: "Preloading with ORMs"}
+	db.Create(&post_orms)
+	db.Create(&post_reflection)
+	db.Create(&post_preloading)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Inner Join Into Composite Struct")
+	results := []AuthorPost{}
+	joins := new(Joins)
+	addJoin(joins, "INNER", &Post{}, "author.ID", "post.WriterID")
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "post.ID", "ASC")
+	db.Find(&results, FindArgs{from: &Author{}, joins: *joins, orderBy: *orderBy})
+	expected := []AuthorPost{
+		{FullName: "Nick", Title: "Intro to ORMs", PostID: post_orms.ID},
+		{FullName: "Shannon", Title: "Reflection in Go", PostID: post_reflection.ID},
+		{FullName: "Nick", Title: "Preloading with ORMs", PostID: post_preloading.ID},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v but instead found %+v", expected, results)
+	}
+
+	fmt.Println("Test: Authors Whose Posts Mention ORMs")
+	authors := []Author{}
+	filter := make(Filter)
+	addFilter(filter, "post.title", "like", "%ORMs%")
+	addFilter(filter, "author.FullName", "neq", "Will")
+	orderBy = new(OrderBy)
+	addOrder(orderBy, "post.Title", "DESC")
+	db.Find(&authors, FindArgs{joins: *joins, andFilter: filter, orderBy: *orderBy, limit: 1})
+	if len(authors) != 1 || authors[0].FullName != "Nick" || authors[0].ID != author_nick.ID {
+		t.Errorf("Expected [Nick] but instead found %+v", authors)
+	}
+
+	fmt.Println("Test: Left Join Keeps Authors Without Posts")
+	results = []AuthorPost{}
+	joins = new(Joins)
+	addJoin(joins, "LEFT", &Post{}, "author.id", "post.writer_id")
+	filter = make(Filter)
+	addFilter(filter, "author.FullName", "in", []interface{}{"Shannon", "Will"})
+	orderBy = new(OrderBy)
+	addOrder(orderBy, "FullName", "ASC")
+	db.Find(&results, FindArgs{from: &Author{}, joins: *joins, andFilter: filter, orderBy: *orderBy})
+	expected = []AuthorPost{
+		{FullName: "Shannon", Title: "Reflection in Go", PostID: post_reflection.ID},
+		{FullName: "Will"},
+	}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v but instead found %+v", expected, results)
+	}
+
+	fmt.Println("Test: Projection on Join")
+	results = []AuthorPost{}
+	joins = new(Joins)
+	addJoin(joins, "INNER", &Post{}, "author.ID", "post.WriterID")
+	filter = make(Filter)
+	addFilter(filter, "author.ID", "eq", author_shannon.ID)
+	db.Find(&results, FindArgs{from: &Author{}, joins: *joins, andFilter: filter, projection: []interface{}{"Title"}})
+	expected = []AuthorPost{{Title: "Reflection in Go"}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v but instead found %+v", expected, results)
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Invalid Join Kind")
+		joins = new(Joins)
+		addJoin(joins, "OUTER", &Post{}, "author.ID", "post.WriterID")
+		db.Find(&results, FindArgs{from: &Author{}, joins: *joins})
+	})
+}
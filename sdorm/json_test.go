@@ -0,0 +1,129 @@
+package sdorm
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func createProfileTable(conn *sql.DB) {
+	_, err := conn.Exec(`create table profile (
+		handle text,
+		settings text,
+		tags text,
+		scores text
+	)`)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+type ProfileSettings struct {
+	Theme    string `json:"theme"`
+	FontSize int    `json:"font_size"`
+}
+
+// Profile Table Schema
+type Profile struct {
+	Handle   string
+	Settings ProfileSettings `dorm:"json"`
+	Tags     []string        `dorm:"json"`
+	Scores   map[string]int  `dorm:"json"`
+}
+
+/*
+	Helper method to test that the resulting profiles match the expected profiles,
+	including the contents of their JSON fields.
+*/
+func helperTestProfileEquality(t *testing.T, results []Profile, expected []Profile) {
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v but instead found %+v", expected, results)
+	}
+}
+
+func TestJSONFields(t *testing.T) {
+	fmt.Println(">>> JSON FIELD TESTS <<<")
+	conn := connectSQL()
+	createProfileTable(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	profile_nick := Profile{
+		Handle:   "nick",
+		Settings: ProfileSettings{Theme: "dark", FontSize: 12},
+		Tags:     []string{"cos316", "go"},
+		Scores:   map[string]int{"midterm": 90},
+	}
+	profile_shannon := Profile{
+		Handle:   "shannon",
+		Settings: ProfileSettings{Theme: "light", FontSize: 14},
+		Tags:     []string{"sql"},
+	}
+	db.Create(&profile_nick)
+	db.Create(&profile_shannon)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Round Trip All Profiles")
+	results := []Profile{}
+	db.Find(&results, FindArgs{})
+	helperTestProfileEquality(t, results, []Profile{
+		profile_nick,
+		profile_shannon,
+	})
+
+	fmt.Println("Test: Stored As JSON Text")
+	var stored string
+	conn.QueryRow("SELECT settings FROM profile WHERE handle = 'nick'").Scan(&stored)
+	if stored != `{"theme":"dark","font_size":12}` {
+		t.Errorf("Expected JSON text but instead found %v", stored)
+	}
+
+	fmt.Println("Test: Filter Settings->theme = light, Only Shannon")
+	results = []Profile{}
+	filter := make(Filter)
+	addFilter(filter, "Settings->theme", "eq", "light")
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestProfileEquality(t, results, []Profile{
+		profile_shannon,
+	})
+
+	fmt.Println("Test: Filter Settings->font_size < 14 and Tags->[0] in (cos316), Only Nick")
+	results = []Profile{}
+	filter = make(Filter)
+	addFilter(filter, "Settings->font_size", "lt", 14)
+	addFilter(filter, "Tags->[0]", "in", []interface{}{"cos316"})
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestProfileEquality(t, results, []Profile{
+		profile_nick,
+	})
+
+	fmt.Println("Test: Project Handle and Tags")
+	results = []Profile{}
+	db.Find(&results, FindArgs{projection: []interface{}{"Handle", "Tags"}})
+	helperTestProfileEquality(t, results, []Profile{
+		{Handle: "nick", Tags: []string{"cos316", "go"}},
+		{Handle: "shannon", Tags: []string{"sql"}},
+	})
+
+	fmt.Println("Test: Update Settings of Shannon")
+	filter = make(Filter)
+	addFilter(filter, "Handle", "eq", "shannon")
+	updates := make(Updates)
+	addUpdate(updates, "Settings", ProfileSettings{Theme: "dark", FontSize: 16})
+	rows_updated := db.Update(&Profile{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+
+	results = []Profile{}
+	filter = make(Filter)
+	addFilter(filter, "Settings->theme", "eq", "dark")
+	db.Find(&results, FindArgs{andFilter: filter})
+	profile_shannon.Settings = ProfileSettings{Theme: "dark", FontSize: 16}
+	helperTestProfileEquality(t, results, []Profile{
+		profile_nick,
+		profile_shannon,
+	})
+}
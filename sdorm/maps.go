@@ -0,0 +1,79 @@
+package sdorm
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	FindMaps queries the table with the given name, without a model, and
+	returns the matching rows as maps from column name to value, so that
+	tools such as table viewers can read tables sdorm has no struct for.
+
+	args is used as in Find: projection, andFilter, orderBy and limit
+	refer to columns by their name in the table (e.g. "full_name") or by
+	the field name the naming strategy maps to it (e.g. "FullName").
+	Joins and preloading need models and are not supported.
+
+	Values are converted to the natural Go type for their column's
+	declared type: INTEGER to int64, REAL to float64, TEXT to string,
+	BLOB to []byte, BOOLEAN to bool and DATETIME to time.Time.
+	NULL becomes nil.
+
+	FindMaps returns an error if the table does not exist, an
+	UnknownFieldError if args name a column it does not have, or an error
+	if the query fails.
+
+	Example usage:
+	filter := make(Filter)
+	addFilter(filter, "age", "gt", 18)
+	rows, err := db.FindMaps("user", FindArgs{andFilter: filter, limit: 10})
+	for _, row := range rows {
+		fmt.Println(row["full_name"], row["age"])
+	}
+*/
+func (db *DB) FindMaps(tablename string, args FindArgs) ([]map[string]interface{}, error) {
+	args = db.applyScopes(args)
+	if len(args.joins) > 0 || len(args.preload) > 0 || args.from != nil {
+		return nil, fmt.Errorf("sdorm: FindMaps does not support joins or preloading")
+	}
+	columns := db.tableColumns(tablename)
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("sdorm: table %v not found", tablename)
+	}
+
+	// each column stands in for a field of the same name
+	fields := make([]modelField, len(columns))
+	for i, column := range columns {
+		fields[i] = modelField{name: column, column: column}
+	}
+
+	// select PROJECTED columns in the order given
+	selected := columns
+	if len(args.projection) > 0 {
+		selected = []string{}
+		for _, name := range args.projection {
+			column := db.fieldColumn(fields, fmt.Sprint(name))
+			if !containsString(columns, column) {
+				return nil, &UnknownFieldError{Field: fmt.Sprint(name), Clause: "projection", Table: tablename, Suggestion: closestName(fmt.Sprint(name), columns)}
+			}
+			selected = append(selected, column)
+		}
+	}
+
+	if err := db.checkQueryFields(tablename, fields, args.andFilter, args.orderBy); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT %v FROM %v", strings.Join(selected, ", "), tablename)
+	where_string, where_args := db.buildWhereString(args.andFilter, fields)
+	query += where_string + db.buildOrderLimitString(args, fields)
+
+	rows, err := db.inner.Query(query, where_args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanMaps(rows)
+}
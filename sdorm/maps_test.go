@@ -0,0 +1,89 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Model of a table read back without it through FindMaps
+type Reading struct {
+	ID      int64 `dorm:"primary_key"`
+	Sensor  string
+	Value   float64
+	Active  bool
+	TakenAt time.Time
+	Payload []byte
+}
+
+func TestFindMaps(t *testing.T) {
+	fmt.Println(">>> FIND MAPS TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Filter, Order and Limit")
+	filter := make(Filter)
+	addFilter(filter, "class_year", "neq", "Sophomore")
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "Age", "DESC")
+	addOrder(orderBy, "full_name", "ASC")
+	rows, err := db.FindMaps("user", FindArgs{andFilter: filter, orderBy: *orderBy, limit: 2})
+	expected := []map[string]interface{}{
+		{"full_name": "Albert", "age": int64(40), "class_year": "Senior", "is_enrolled": int64(1)},
+		{"full_name": "Shannon", "age": int64(20), "class_year": "Freshman", "is_enrolled": int64(0)},
+	}
+	if err != nil || !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v but instead found %v (error %v)", expected, rows, err)
+	}
+
+	fmt.Println("Test: Projection")
+	filter = make(Filter)
+	addFilter(filter, "FullName", "in", []interface{}{"Nick", "Katie"})
+	orderBy = new(OrderBy)
+	addOrder(orderBy, "age", "ASC")
+	rows, err = db.FindMaps("user", FindArgs{projection: []interface{}{"age", "FullName"}, andFilter: filter, orderBy: *orderBy})
+	expected = []map[string]interface{}{
+		{"full_name": "Nick", "age": int64(10)},
+		{"full_name": "Katie", "age": int64(30)},
+	}
+	if err != nil || !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v but instead found %v (error %v)", expected, rows, err)
+	}
+
+	fmt.Println("Test: Errors")
+	if _, err = db.FindMaps("nowhere", FindArgs{}); err == nil {
+		t.Errorf("Expected an error for a missing table but got none")
+	}
+	if _, err = db.FindMaps("user", FindArgs{projection: []interface{}{"nickname"}}); err == nil {
+		t.Errorf("Expected an error for a missing column but got none")
+	}
+}
+
+func TestFindMapsTypes(t *testing.T) {
+	fmt.Println(">>> FIND MAPS TYPES TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+
+	db.AutoMigrate(&Reading{})
+	taken_at := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	db.Create(&Reading{Sensor: "north", Value: 1.5, Active: true, TakenAt: taken_at, Payload: []byte{1, 2}})
+	if _, err := db.Exec("INSERT INTO reading (sensor) VALUES (?)", "south"); err != nil {
+		panic(err)
+	}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Natural Go Types")
+	rows, err := db.FindMaps("reading", FindArgs{})
+	expected := []map[string]interface{}{
+		{"id": int64(1), "sensor": "north", "value": 1.5, "active": true, "taken_at": taken_at, "payload": []byte{1, 2}},
+		{"id": int64(2), "sensor": "south", "value": nil, "active": nil, "taken_at": nil, "payload": nil},
+	}
+	if err != nil || !reflect.DeepEqual(rows, expected) {
+		t.Errorf("Expected %v but instead found %v (error %v)", expected, rows, err)
+	}
+}
@@ -0,0 +1,101 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+)
+
+/*
+	AutoMigrate creates the table of each of the given models, if it does
+	not exist yet, with one column per field of the model (see modelFields).
+	If the table already exists, columns for any new fields are added to it.
+	Existing columns are never changed or dropped.
+
+	The join tables of the models' many2many relationships are created as
+	well, with one column for the key of each model and a primary key on
+	the pair.
+
+	Column types are derived from field types: integers become INTEGER,
+	bools BOOLEAN, floats REAL, strings TEXT, []byte BLOB, time.Time
+	DATETIME and `dorm:"json"` fields TEXT. An integer field tagged `dorm:"primary_key"`
+	becomes an INTEGER PRIMARY KEY, which SQLite assigns on insert.
+
+	AutoMigrate panics if a generated statement fails.
+
+	Example usage:
+	db.AutoMigrate(&User{}, &Course{})
+*/
+func (db *DB) AutoMigrate(models ...interface{}) {
+	for _, model := range models {
+		t := modelType(model)
+		tablename := db.tableName(model)
+		fields := db.modelFields(t)
+
+		existing := db.tableColumns(tablename)
+		if len(existing) == 0 {
+			columns := make([]string, len(fields))
+			for i, field := range fields {
+				columns[i] = field.column + " " + sqlType(field)
+			}
+			db.migrate(fmt.Sprintf("CREATE TABLE %v (%v)", tablename, strings.Join(columns, ", ")))
+		} else {
+			for _, field := range fields {
+				if !containsString(existing, field.column) {
+					db.migrate(fmt.Sprintf("ALTER TABLE %v ADD COLUMN %v %v", tablename, field.column, sqlType(field)))
+				}
+			}
+		}
+
+		for _, rel := range db.modelRelations(t) {
+			if rel.kind != many2many {
+				continue
+			}
+			db.migrate(fmt.Sprintf(
+				"CREATE TABLE IF NOT EXISTS %v (%v INTEGER, %v INTEGER, PRIMARY KEY (%v, %v))",
+				rel.joinTable, rel.joinForeignKey, rel.joinReferences, rel.joinForeignKey, rel.joinReferences,
+			))
+		}
+	}
+}
+
+// Executes a statement that changes the schema
+func (db *DB) migrate(statement string) {
+	// also drop tables cached while the statement runs
+	db.tables.clear()
+	defer db.tables.clear()
+	if _, err := db.inner.Exec(statement); err != nil {
+		log.Panic(err)
+	}
+}
+
+// Returns the SQLite column type declaration for a model field
+func sqlType(field modelField) string {
+	t := field.field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	column_type := "TEXT"
+	switch {
+	case isJSONField(field.field):
+		column_type = "TEXT"
+	case t == reflect.TypeOf(time.Time{}):
+		column_type = "DATETIME"
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		column_type = "BLOB"
+	case t.Kind() == reflect.Bool:
+		// stored as 0 or 1, but read back as bool by untyped readers such as FindMaps
+		column_type = "BOOLEAN"
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		column_type = "INTEGER"
+		if isPrimaryKey(field) {
+			column_type = "INTEGER PRIMARY KEY"
+		}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		column_type = "REAL"
+	}
+	return column_type
+}
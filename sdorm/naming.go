@@ -0,0 +1,102 @@
+package sdorm
+
+import (
+	"log"
+	"reflect"
+)
+
+/*
+	NamingStrategy decides how models and their fields map to names in the
+	database. Every DB has one, set with SetNamingStrategy, and uses it for
+	every table, column and join table name it generates.
+
+	- TableName: given the name of a model's Go type (e.g. "UserComment"),
+	  returns the name of its table
+	- ColumnName: given the name of a struct field (e.g. "FullName"),
+	  returns the name of its column
+	- JoinTableName: given the join table named in a `many2many` tag,
+	  returns the name of the table to use
+
+	Strategies that only need to change some names can embed SnakeCaseNaming
+	and override the rest. Example usage:
+	type LegacyNaming struct {
+		SnakeCaseNaming
+	}
+	func (n LegacyNaming) TableName(typeName string) string {
+		return n.SnakeCaseNaming.TableName(typeName) + "s"
+	}
+	db.SetNamingStrategy(LegacyNaming{})
+*/
+type NamingStrategy interface {
+	TableName(typeName string) string
+	ColumnName(fieldName string) string
+	JoinTableName(joinTable string) string
+}
+
+/*
+	TableNamer can be implemented by a model to choose its own table name,
+	overriding the DB's NamingStrategy.
+
+	Example usage:
+	type Person struct { ... }
+	func (Person) TableName() string {
+		return "people"
+	}
+*/
+type TableNamer interface {
+	TableName() string
+}
+
+// SnakeCaseNaming is the default NamingStrategy. It converts type and
+// field names to underscore_case, e.g. UserComment ==> user_comment.
+type SnakeCaseNaming struct{}
+
+func (SnakeCaseNaming) TableName(typeName string) string {
+	return camelToSnake(typeName)
+}
+
+func (SnakeCaseNaming) ColumnName(fieldName string) string {
+	return camelToSnake(fieldName)
+}
+
+func (SnakeCaseNaming) JoinTableName(joinTable string) string {
+	return camelToSnake(joinTable)
+}
+
+// SetNamingStrategy makes db use naming for all table and column names.
+// Passing nil restores the default SnakeCaseNaming.
+func (db *DB) SetNamingStrategy(naming NamingStrategy) {
+	if naming == nil {
+		naming = SnakeCaseNaming{}
+	}
+	db.naming = naming
+}
+
+// Returns the name of the table for a model (or pointer or slice of models)
+// according to db's naming strategy
+func (db *DB) tableName(model interface{}) string {
+	return tableNameWith(model, db.naming)
+}
+
+// Returns the name of the table for a model, asking the model itself first
+// if it implements TableNamer and otherwise deferring to naming
+func tableNameWith(model interface{}, naming NamingStrategy) string {
+	t := modelType(model)
+	if namer, ok := reflect.New(t).Interface().(TableNamer); ok {
+		return namer.TableName()
+	}
+	if t.Name() == "" {
+		log.Panicf("Cannot derive a table name for unnamed type %v!", t)
+	}
+	return naming.TableName(t.Name())
+}
+
+// Strips pointers and slices from the type of model, returning
+// the underlying model type (e.g. *[]User ==> User)
+func modelType(model interface{}) reflect.Type {
+	t := reflect.TypeOf(model)
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t
+}
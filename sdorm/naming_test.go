@@ -0,0 +1,157 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Naming strategy for a legacy schema with plural table names and prefixed columns
+type legacyNaming struct {
+	SnakeCaseNaming
+}
+
+func (n legacyNaming) TableName(typeName string) string {
+	return n.SnakeCaseNaming.TableName(typeName) + "s"
+}
+
+func (n legacyNaming) ColumnName(fieldName string) string {
+	return "col_" + n.SnakeCaseNaming.ColumnName(fieldName)
+}
+
+// Person Table Schema, stored in the "people" table
+type Person struct {
+	FullName string
+}
+
+func (Person) TableName() string {
+	return "people"
+}
+
+func TestTableName(t *testing.T) {
+	fmt.Println(">>> TABLE NAME TESTS <<<")
+
+	fmt.Println("Test: Model, Pointer and Slice")
+	for _, model := range []interface{}{User{}, &User{}, &[]User{}} {
+		if name := TableName(model); name != "user" {
+			t.Errorf("Expected user but instead found %v", name)
+		}
+	}
+
+	fmt.Println("Test: TableNamer")
+	if name := TableName(&Person{}); name != "people" {
+		t.Errorf("Expected people but instead found %v", name)
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Unnamed Type")
+		TableName(&struct{ FullName string }{})
+	})
+}
+
+func TestNamingStrategy(t *testing.T) {
+	fmt.Println(">>> NAMING STRATEGY TESTS <<<")
+	conn := connectSQL()
+	_, err := conn.Exec(`create table users (
+		col_full_name text,
+		col_age int,
+		col_class_year text,
+		col_is_enrolled int
+	)`)
+	if err != nil {
+		panic(err)
+	}
+	_, err = conn.Exec(`create table people (col_full_name text)`)
+	if err != nil {
+		panic(err)
+	}
+
+	db := NewDB(conn)
+	db.SetNamingStrategy(legacyNaming{})
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10}
+	user_shannon := User{FullName: "Shannon", ClassYear: "Senior", Age: 20}
+	db.Create(&user_nick)
+	db.Create(&user_shannon)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Find With Filter and Order")
+	results := []User{}
+	filter := make(Filter)
+	addFilter(filter, "Age", "geq", 10)
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "FullName", "DESC")
+	db.Find(&results, FindArgs{andFilter: filter, orderBy: *orderBy})
+	helperTestEquality(t, results, []User{
+		user_shannon,
+		user_nick,
+	})
+
+	fmt.Println("Test: Update and Delete")
+	filter = make(Filter)
+	addFilter(filter, "FullName", "eq", "Nick")
+	updates := make(Updates)
+	addUpdate(updates, "Age", 11)
+	helperTestIntEquality(t, db.Update(&User{}, DeleteOrUpdateArgs{andFilter: filter}, updates), 1)
+	helperTestIntEquality(t, db.Delete(&User{}, DeleteOrUpdateArgs{andFilter: filter}), 1)
+
+	fmt.Println("Test: TableNamer Overrides Strategy")
+	db.Create(&Person{FullName: "Will"})
+	people := []Person{}
+	db.Find(&people, FindArgs{projection: []interface{}{"FullName"}})
+	if len(people) != 1 || people[0].FullName != "Will" {
+		t.Errorf("Expected Will but instead found %v", people)
+	}
+}
+
+func TestCamelToSnake(t *testing.T) {
+	fmt.Println(">>> CAMEL TO SNAKE TESTS <<<")
+
+	tests := []struct {
+		camel string
+		snake string
+	}{
+		// plain words
+		{"", ""},
+		{"A", "a"},
+		{"Age", "age"},
+		{"FullName", "full_name"},
+		{"UserComment", "user_comment"},
+		{"isEnrolled", "is_enrolled"},
+		// acronyms
+		{"ID", "id"},
+		{"UserID", "user_id"},
+		{"IDNumber", "id_number"},
+		{"HTTPStatus", "http_status"},
+		{"StatusHTTP", "status_http"},
+		{"JSONAPIKey", "jsonapi_key"},
+		// a single capital before a word stands alone, as in x_axis, so
+		// columns named before the rewrite keep their names
+		{"XAxis", "x_axis"},
+		{"OAuth", "o_auth"},
+		// plural acronyms
+		{"IDs", "ids"},
+		{"UserIDs", "user_ids"},
+		{"URLsSeen", "urls_seen"},
+		{"HTTPServer", "http_server"},
+		// digits
+		{"Address2", "address2"},
+		{"Line2Text", "line2_text"},
+		{"OAuth2Token", "o_auth2_token"},
+		{"Base64URL", "base64_url"},
+		{"Sha256Sum", "sha256_sum"},
+		{"V2API", "v2_api"},
+		{"HTTP2Server", "http2_server"},
+		// existing underscores
+		{"already_snake", "already_snake"},
+		{"User_ID", "user_id"},
+		{"_Private", "_private"},
+	}
+
+	for _, test := range tests {
+		if snake := camelToSnake(test.camel); snake != test.snake {
+			t.Errorf("camelToSnake(%q): expected %q but instead found %q", test.camel, test.snake, snake)
+		}
+	}
+}
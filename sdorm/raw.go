@@ -0,0 +1,138 @@
+package sdorm
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+	Raw runs a query written by hand, for when Find cannot express it,
+	and stores its rows in result, which must be a pointer to one of:
+	- a slice of models: one struct per row, with columns matched to
+	  fields by name just as in Find
+	- a model: the first row, or unchanged if there are no rows
+	- a []map[string]interface{}: one map per row, from column name to value
+	- a slice of scalars (e.g. []int): the first column of each row
+	- a scalar (e.g. int): the first column of the first row,
+	  or unchanged if there are no rows
+
+	The query may use "?" placeholders, bound in order to args.
+
+	A query other than a SELECT, such as an UPDATE with a RETURNING
+	clause, may write any table or change the schema, so Raw drops every
+	snapshot kept for Save (see SetTrackChanges) and every cached table
+	when it runs one.
+
+	Raw returns an error if the query fails or a column value cannot be
+	stored in result.
+
+	Example usage:
+	type AgeCount struct {
+		Age   int
+		Count int
+	}
+	results := []AgeCount{}
+	err := db.Raw(&results, "SELECT age, COUNT(*) AS count FROM user WHERE age > ? GROUP BY age", 18)
+*/
+func (db *DB) Raw(result interface{}, query string, args ...interface{}) error {
+	dst := reflect.ValueOf(result)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() {
+		return fmt.Errorf("sdorm: Raw result must be a non-nil pointer, not %T", result)
+	}
+
+	if !isReadOnlyQuery(query) {
+		// the statement may change the schema
+		db.tables.clear()
+		defer db.tables.clear()
+		defer db.snapshots.clear()
+	}
+	rows, err := db.inner.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	return db.scanInto(rows, dst.Elem())
+}
+
+/*
+	Exec runs a statement written by hand, such as an INSERT, UPDATE or
+	DELETE, returning the number of rows it affected.
+
+	The statement may use "?" placeholders, bound in order to args.
+
+	The statement may write any table or change the schema, so Exec drops
+	every snapshot kept for Save (see SetTrackChanges) and every cached
+	table.
+
+	Example usage:
+	rows_updated, err := db.Exec("UPDATE user SET age = age + 1 WHERE class_year = ?", "Senior")
+*/
+func (db *DB) Exec(query string, args ...interface{}) (int, error) {
+	// the statement may change the schema; also drop tables cached while it runs
+	db.tables.clear()
+	defer db.tables.clear()
+	defer db.snapshots.clear()
+	res, err := db.inner.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	rows_affected, err := res.RowsAffected()
+	return int(rows_affected), err
+}
+
+// Stores every remaining row of rows in dst, which holds a model, map or
+// scalar, or a slice of them (see Raw)
+func (db *DB) scanInto(rows *sql.Rows, dst reflect.Value) error {
+	elem := dst.Type()
+	is_slice := elem.Kind() == reflect.Slice && elem.Elem().Kind() != reflect.Uint8
+	if is_slice {
+		elem = elem.Elem()
+	}
+
+	var values []reflect.Value
+	var err error
+	switch {
+	case elem.Kind() == reflect.Map:
+		if elem != reflect.TypeOf(map[string]interface{}{}) {
+			return fmt.Errorf("sdorm: cannot scan rows into %v, use map[string]interface{}", elem)
+		}
+		var maps []map[string]interface{}
+		maps, err = scanMaps(rows)
+		for _, row := range maps {
+			values = append(values, reflect.ValueOf(row))
+		}
+	case isModelType(elem):
+		values, err = scanStructs(rows, elem, db.modelFields(elem))
+	default:
+		values, err = scanScalars(rows, elem)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !is_slice {
+		if len(values) > 0 {
+			dst.Set(values[0])
+		}
+		return nil
+	}
+	for _, value := range values {
+		dst.Set(reflect.Append(dst, value))
+	}
+	return nil
+}
+
+// Checks if t is a model struct with fields of its own, rather than
+// a struct holding a single value such as time.Time or sql.NullString
+func isModelType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && isFlattenable(t, nil)
+}
+
+// Reports whether query is a SELECT, which reads rows without writing any
+func isReadOnlyQuery(query string) bool {
+	fields := strings.Fields(query)
+	return len(fields) > 0 && strings.EqualFold(fields[0], "SELECT")
+}
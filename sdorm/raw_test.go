@@ -0,0 +1,124 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestRaw(t *testing.T) {
+	fmt.Println(">>> RAW TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10, IsEnrolled: true}
+	user_will := User{FullName: "Will", ClassYear: "Senior", Age: 20, IsEnrolled: true}
+	user_albert := User{FullName: "Albert", ClassYear: "Senior", Age: 40, IsEnrolled: true}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Slice of Models")
+	results := []User{}
+	err := db.Raw(&results, "SELECT * FROM user WHERE is_enrolled = ? ORDER BY age", true)
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	helperTestEquality(t, results, []User{
+		user_nick,
+		user_will,
+		user_albert,
+	})
+
+	fmt.Println("Test: Slice of Composite Structs")
+	type YearCount struct {
+		ClassYear string
+		Count     int
+		MaxAge    int
+	}
+	counts := []YearCount{}
+	err = db.Raw(&counts, "SELECT class_year, COUNT(*) AS count, MAX(age) AS max_age FROM user GROUP BY class_year ORDER BY class_year")
+	expected_counts := []YearCount{
+		{ClassYear: "Freshman", Count: 2, MaxAge: 20},
+		{ClassYear: "Senior", Count: 2, MaxAge: 40},
+		{ClassYear: "Sophomore", Count: 1, MaxAge: 30},
+	}
+	if err != nil || !reflect.DeepEqual(counts, expected_counts) {
+		t.Errorf("Expected %v but instead found %v (error %v)", expected_counts, counts, err)
+	}
+
+	fmt.Println("Test: Single Model")
+	user := User{}
+	err = db.Raw(&user, "SELECT full_name, age FROM user WHERE age > ? ORDER BY age DESC", 30)
+	if err != nil || user.FullName != "Albert" || user.Age != 40 || user.ClassYear != "" {
+		t.Errorf("Expected %+v but instead found %+v (error %v)", User{FullName: "Albert", Age: 40}, user, err)
+	}
+
+	fmt.Println("Test: Single Model, No Rows")
+	user = User{FullName: "Unchanged"}
+	err = db.Raw(&user, "SELECT * FROM user WHERE age < 0")
+	if err != nil || user.FullName != "Unchanged" {
+		t.Errorf("Expected user to be unchanged but instead found %+v (error %v)", user, err)
+	}
+
+	fmt.Println("Test: Scalars")
+	var count int
+	err = db.Raw(&count, "SELECT COUNT(*) FROM user WHERE class_year = ?", "Senior")
+	if err != nil || count != 2 {
+		t.Errorf("Expected 2 but instead found %v (error %v)", count, err)
+	}
+	names := []string{}
+	err = db.Raw(&names, "SELECT full_name FROM user WHERE age = ? ORDER BY full_name", 20)
+	if err != nil || !reflect.DeepEqual(names, []string{"Shannon", "Will"}) {
+		t.Errorf("Expected [Shannon Will] but instead found %v (error %v)", names, err)
+	}
+
+	fmt.Println("Test: Maps")
+	maps := []map[string]interface{}{}
+	err = db.Raw(&maps, "SELECT full_name, age FROM user WHERE full_name IN (?, ?) ORDER BY age", "Katie", "Nick")
+	expected_maps := []map[string]interface{}{
+		{"full_name": "Nick", "age": int64(10)},
+		{"full_name": "Katie", "age": int64(30)},
+	}
+	if err != nil || !reflect.DeepEqual(maps, expected_maps) {
+		t.Errorf("Expected %v but instead found %v (error %v)", expected_maps, maps, err)
+	}
+
+	fmt.Println("Test: Invalid Query")
+	if err = db.Raw(&results, "SELECT * FROM nowhere"); err == nil {
+		t.Errorf("Expected an error but got none")
+	}
+
+	fmt.Println("Test: Non-Pointer Result")
+	if err = db.Raw(results, "SELECT * FROM user"); err == nil {
+		t.Errorf("Expected an error but got none")
+	}
+}
+
+func TestExec(t *testing.T) {
+	fmt.Println(">>> EXEC TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Update Seniors")
+	rows_updated, err := db.Exec("UPDATE user SET age = age + ? WHERE class_year = ?", 1, "Senior")
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	helperTestIntEquality(t, rows_updated, 2)
+
+	results := []User{}
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestEquality(t, results, []User{
+		{FullName: "Will", ClassYear: "Senior", Age: 21},
+		{FullName: "Albert", ClassYear: "Senior", Age: 41},
+	})
+
+	fmt.Println("Test: Invalid Statement")
+	if _, err = db.Exec("DELETE FROM nowhere"); err == nil {
+		t.Errorf("Expected an error but got none")
+	}
+}
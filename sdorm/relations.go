@@ -0,0 +1,316 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+)
+
+/*
+	A relationship between a model and another model, declared by a
+	struct field of the other model's type.
+
+	- a field holding a slice of models declares a has-many relationship.
+	  The other model's table holds a foreign key column referring to this
+	  model's primary key, named after this model by default:
+	  type User struct {
+		ID       int64 `dorm:"primary_key"`
+		Comments []UserComment `dorm:"foreign_key:user_id"`
+	  }
+	- a field holding a model (or a pointer to one) declares a belongs-to
+	  relationship. This model's table holds a foreign key column referring
+	  to the other model's primary key, named after the field by default:
+	  type UserComment struct {
+		ID     int64 `dorm:"primary_key"`
+		UserID int64
+		User   *User `dorm:"foreign_key:user_id"`
+	  }
+	- a slice field tagged `dorm:"many2many:<join table>"` declares a
+	  many-to-many relationship through a join table holding pairs of
+	  primary keys. The join table's columns are named after each model,
+	  e.g. user_id and course_id, and AutoMigrate creates it:
+	  type User struct {
+		ID      int64 `dorm:"primary_key"`
+		Courses []Course `dorm:"many2many:user_courses"`
+	  }
+
+	The primary key of a model is the field tagged `dorm:"primary_key"`;
+	a field named ID is not a primary key without the tag. Relationship
+	fields are not columns, so they are ignored by Create, Update and Find
+	unless they are preloaded.
+*/
+type relation struct {
+	name       string
+	kind       string
+	index      []int
+	field      reflect.StructField
+	target     reflect.Type
+	foreignKey string
+
+	// for many2many relationships only: the join table, and its columns
+	// holding this model's key and the other model's key
+	joinTable      string
+	joinForeignKey string
+	joinReferences string
+}
+
+// Relationship kinds
+const (
+	hasMany   = "has_many"
+	belongsTo = "belongs_to"
+	many2many = "many2many"
+)
+
+// Checks if a struct field of type t declares a relationship
+// rather than holding a column
+func isRelationField(t reflect.Type, settings map[string]string) bool {
+	if _, ok := settings["json"]; ok {
+		return false
+	}
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isFlattenable(t, settings)
+}
+
+// Returns all relationships declared by fields of model type t
+func (db *DB) modelRelations(t reflect.Type) []relation {
+	relations := []relation{}
+	for i := 0; i < t.NumField(); i++ {
+		if rel, ok := db.modelRelation(t, t.Field(i).Name); ok {
+			relations = append(relations, rel)
+		}
+	}
+	return relations
+}
+
+// Returns the relationship declared by the field of model type t called name,
+// or false if there is no such relationship field
+func (db *DB) modelRelation(t reflect.Type, name string) (relation, bool) {
+	field, ok := t.FieldByName(name)
+	settings := tagSettings(field)
+	// embedded structs hold columns, never relationships
+	if !ok || field.Anonymous || !isRelationField(field.Type, settings) {
+		return relation{}, false
+	}
+
+	rel := relation{name: field.Name, index: field.Index, field: field, foreignKey: settings["foreign_key"]}
+	target := field.Type
+	if join_table, ok := settings["many2many"]; ok && target.Kind() == reflect.Slice {
+		rel.kind = many2many
+		target = target.Elem()
+		if target.Kind() == reflect.Ptr {
+			target = target.Elem()
+		}
+		rel.joinTable = db.naming.JoinTableName(join_table)
+		rel.joinForeignKey = db.naming.ColumnName(t.Name() + "ID")
+		rel.joinReferences = db.naming.ColumnName(target.Name() + "ID")
+	} else if target.Kind() == reflect.Slice {
+		rel.kind = hasMany
+		target = target.Elem()
+		if rel.foreignKey == "" {
+			rel.foreignKey = db.naming.ColumnName(t.Name() + "ID")
+		}
+	} else {
+		rel.kind = belongsTo
+		if rel.foreignKey == "" {
+			rel.foreignKey = db.naming.ColumnName(field.Name + "ID")
+		}
+	}
+	if target.Kind() == reflect.Ptr {
+		target = target.Elem()
+	}
+	rel.target = target
+	return rel, true
+}
+
+/*
+	Preload returns a copy of args that also loads the named relationship
+	fields of every row found (see the comment above relation for how
+	relationships are declared).
+
+	Each relationship is loaded with one extra query, selecting the related
+	rows of all found rows at once with an IN filter, so finding N rows with
+	one preload takes two queries rather than N+1. The primary and foreign
+	key fields involved must be included in any projection.
+
+	Example usage to find users along with their comments:
+	results := []User{}
+	db.Find(&results, FindArgs{}.Preload("Comments"))
+*/
+func (args FindArgs) Preload(names ...string) FindArgs {
+	args.preload = append(append([]string{}, args.preload...), names...)
+	return args
+}
+
+// Loads the relationship field called name for each struct in the slice parents
+func (db *DB) preload(parents reflect.Value, name string) {
+	elem := parents.Type().Elem()
+	rel, ok := db.modelRelation(elem, name)
+	if !ok {
+		log.Panicf("Relationship %v not found on %v!", name, elem.Name())
+	}
+	if parents.Len() == 0 {
+		return
+	}
+
+	parent_fields := db.modelFields(elem)
+	target_fields := db.modelFields(rel.target)
+
+	if rel.kind == many2many {
+		db.preloadMany2Many(parents, rel, parent_fields, target_fields)
+		return
+	}
+
+	// the field holding the key in the parent, and the field
+	// holding the matching key in the related rows
+	var parent_key, target_key modelField
+	switch rel.kind {
+	case hasMany:
+		parent_key = requirePrimaryKey(parent_fields, elem)
+		target_key = requireKeyColumn(target_fields, rel.target, rel.foreignKey)
+	case belongsTo:
+		parent_key = requireKeyColumn(parent_fields, elem, rel.foreignKey)
+		target_key = requirePrimaryKey(target_fields, rel.target)
+	}
+
+	// find all related rows at once
+	keys := make([]interface{}, 0, parents.Len())
+	for i := 0; i < parents.Len(); i++ {
+		keys = append(keys, parents.Index(i).FieldByIndex(parent_key.index).Interface())
+	}
+	related := db.findRelated(rel.target, target_key, keys)
+
+	// group related rows by key
+	// keys are compared by their printed value, so that e.g. an int
+	// foreign key matches an int64 primary key
+	groups := make(map[string][]reflect.Value)
+	for i := 0; i < related.Len(); i++ {
+		key := fmt.Sprint(related.Index(i).FieldByIndex(target_key.index).Interface())
+		groups[key] = append(groups[key], related.Index(i))
+	}
+
+	// assign related rows to each parent
+	for i := 0; i < parents.Len(); i++ {
+		parent := parents.Index(i)
+		key := fmt.Sprint(parent.FieldByIndex(parent_key.index).Interface())
+		assignRelated(parent.FieldByIndex(rel.index), groups[key])
+	}
+}
+
+// Loads a many2many relationship field for each struct in the slice parents,
+// by finding all of their join table rows and then all of the related rows
+func (db *DB) preloadMany2Many(parents reflect.Value, rel relation, parent_fields []modelField, target_fields []modelField) {
+	parent_key := requirePrimaryKey(parent_fields, parents.Type().Elem())
+	target_key := requirePrimaryKey(target_fields, rel.target)
+
+	keys := make([]interface{}, 0, parents.Len())
+	for i := 0; i < parents.Len(); i++ {
+		keys = append(keys, parents.Index(i).FieldByIndex(parent_key.index).Interface())
+	}
+
+	// find the pairs of keys in the join table
+	query := fmt.Sprintf("SELECT %v, %v FROM %v WHERE %v IN (%v)", rel.joinForeignKey, rel.joinReferences, rel.joinTable, rel.joinForeignKey, placeholders(len(keys)))
+	rows, err := db.inner.Query(query, keys...)
+	if err != nil {
+		log.Panic(err)
+	}
+	pairs := [][2]string{}
+	target_keys := []interface{}{}
+	for rows.Next() {
+		var parent_id, target_id interface{}
+		if err := rows.Scan(&parent_id, &target_id); err != nil {
+			log.Panic(err)
+		}
+		pairs = append(pairs, [2]string{fmt.Sprint(parent_id), fmt.Sprint(target_id)})
+		target_keys = append(target_keys, target_id)
+	}
+	rows.Close()
+	if len(target_keys) == 0 {
+		return
+	}
+
+	// find the related rows, and index them by key
+	related := db.findRelated(rel.target, target_key, target_keys)
+	by_key := make(map[string]reflect.Value)
+	for i := 0; i < related.Len(); i++ {
+		by_key[fmt.Sprint(related.Index(i).FieldByIndex(target_key.index).Interface())] = related.Index(i)
+	}
+
+	// group related rows by parent key, in join table order
+	groups := make(map[string][]reflect.Value)
+	for _, pair := range pairs {
+		if value, ok := by_key[pair[1]]; ok {
+			groups[pair[0]] = append(groups[pair[0]], value)
+		}
+	}
+	for i := 0; i < parents.Len(); i++ {
+		parent := parents.Index(i)
+		key := fmt.Sprint(parent.FieldByIndex(parent_key.index).Interface())
+		assignRelated(parent.FieldByIndex(rel.index), groups[key])
+	}
+}
+
+// Finds the rows of the target model whose key field is in keys,
+// returning them as a slice of target structs
+func (db *DB) findRelated(target reflect.Type, key modelField, keys []interface{}) reflect.Value {
+	related := reflect.New(reflect.SliceOf(target))
+	filter := make(Filter)
+	addFilter(filter, key.name, "in", keys)
+	db.Find(related.Interface(), FindArgs{andFilter: filter})
+	return related.Elem()
+}
+
+// Stores related structs in a relationship field, which may hold
+// a slice of structs or pointers, a struct, or a pointer to a struct
+func assignRelated(field reflect.Value, related []reflect.Value) {
+	switch field.Kind() {
+	case reflect.Slice:
+		slice := reflect.MakeSlice(field.Type(), 0, len(related))
+		for _, value := range related {
+			slice = reflect.Append(slice, addressOrValue(value, field.Type().Elem()))
+		}
+		field.Set(slice)
+	default:
+		if len(related) > 0 {
+			field.Set(addressOrValue(related[0], field.Type()))
+		}
+	}
+}
+
+// Returns n comma-separated "?" placeholders, for an IN list of n values
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?,", n), ",")
+}
+
+// Returns value, or a pointer to a copy of it if t is a pointer type
+func addressOrValue(value reflect.Value, t reflect.Type) reflect.Value {
+	if t.Kind() != reflect.Ptr {
+		return value
+	}
+	ptr := reflect.New(t.Elem())
+	ptr.Elem().Set(value)
+	return ptr
+}
+
+// Returns the primary key field of a model, panicking if it has none
+func requirePrimaryKey(fields []modelField, t reflect.Type) modelField {
+	field, ok := primaryKeyField(fields)
+	if !ok {
+		log.Panicf("Model %v has no primary key!", t.Name())
+	}
+	return field
+}
+
+// Returns the field of a model stored in column, panicking if it has none
+func requireKeyColumn(fields []modelField, t reflect.Type, column string) modelField {
+	field, ok := snakeToField(fields, column)
+	if !ok {
+		log.Panicf("Model %v has no field for key column %v!", t.Name(), column)
+	}
+	return field
+}
@@ -0,0 +1,132 @@
+package sdorm
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+)
+
+func createAuthorTables(conn *sql.DB) {
+	_, err := conn.Exec(`create table author (
+		id integer primary key,
+		full_name text
+	)`)
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = conn.Exec(`create table post (
+		id integer primary key,
+		writer_id int,
+		title text
+	)`)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Author Table Schema
+type Author struct {
+	ID       int64 `dorm:"primary_key"`
+	FullName string
+	Posts    []Post `dorm:"foreign_key:writer_id"`
+}
+
+// Post Table Schema
+type Post struct {
+	ID       int64 `dorm:"primary_key"`
+	WriterID int
+	Title    string
+	Writer   *Author
+}
+
+/*
+	Helper method to test that each author has the expected post titles, in order.
+*/
+func helperTestPosts(t *testing.T, authors []Author, expected map[string][]string) {
+	if len(authors) != len(expected) {
+		t.Errorf("Expected %v authors but instead found %v authors", len(expected), len(authors))
+	}
+	for _, author := range authors {
+		titles := []string{}
+		for _, post := range author.Posts {
+			titles = append(titles, post.Title)
+		}
+		if fmt.Sprint(titles) != fmt.Sprint(expected[author.FullName]) {
+			t.Errorf("Expected %v to have posts %v but instead found %v", author.FullName, expected[author.FullName], titles)
+		}
+	}
+}
+
+func TestPreload(t *testing.T) {
+	fmt.Println(">>> PRELOAD TESTS <<<")
+	conn := connectSQL()
+	createAuthorTables(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	author_nick := Author{FullName: "Nick"}
+	author_shannon := Author{FullName: "Shannon"}
+	author_will := Author{FullName: "Will"}
+	db.Create(&author_nick)
+	db.Create(&author_shannon)
+	db.Create(&author_will)
+
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Intro to ORMs"})
+	db.Create(&Post{WriterID: int(author_shannon.ID), Title: "Reflection in Go"})
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Preloading"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Has Many")
+	authors := []Author{}
+	db.Find(&authors, FindArgs{}.Preload("Posts"))
+	helperTestPosts(t, authors, map[string][]string{
+		"Nick":    {"Intro to ORMs", "Preloading"},
+		"Shannon": {"Reflection in Go"},
+		"Will":    {},
+	})
+
+	fmt.Println("Test: Has Many With Filter")
+	authors = []Author{}
+	filter := make(Filter)
+	addFilter(filter, "FullName", "eq", "Shannon")
+	db.Find(&authors, FindArgs{andFilter: filter}.Preload("Posts"))
+	helperTestPosts(t, authors, map[string][]string{
+		"Shannon": {"Reflection in Go"},
+	})
+
+	fmt.Println("Test: Without Preload")
+	authors = []Author{}
+	db.Find(&authors, FindArgs{})
+	helperTestPosts(t, authors, map[string][]string{
+		"Nick":    {},
+		"Shannon": {},
+		"Will":    {},
+	})
+
+	fmt.Println("Test: Belongs To")
+	posts := []Post{}
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "Title", "ASC")
+	db.Find(&posts, FindArgs{orderBy: *orderBy}.Preload("Writer"))
+	expected := [][]string{
+		{"Intro to ORMs", "Nick"},
+		{"Preloading", "Nick"},
+		{"Reflection in Go", "Shannon"},
+	}
+	if len(posts) != len(expected) {
+		t.Errorf("Expected %v posts but instead found %v posts", len(expected), len(posts))
+	}
+	for i, post := range posts {
+		if post.Writer == nil || post.Title != expected[i][0] || post.Writer.FullName != expected[i][1] {
+			t.Errorf("Expected %v but instead found %+v", expected[i], post)
+		}
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Unknown Relationship")
+		db.Find(&authors, FindArgs{}.Preload("Comments"))
+	})
+}
@@ -0,0 +1,146 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+)
+
+/*
+	CreateReturning inserts model, a pointer to a model struct, as Create
+	does, and reads the inserted row back into it in the same statement
+	(with SQLite's RETURNING clause), so that model holds the row as
+	stored, including a primary key assigned by the database.
+
+	CreateReturning returns the error Create would panic with.
+
+	Example usage:
+	user := User{FullName: "Nick", Age: 10}
+	err := db.CreateReturning(&user)
+*/
+func (db *DB) CreateReturning(model interface{}) (err error) {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sdorm: CreateReturning requires a pointer to a struct, not %T", model)
+	}
+	defer recoverError(&err)
+	db.withReturning(model).Create(model)
+	return nil
+}
+
+/*
+	UpdateReturning updates rows as Update does, and stores the updated
+	rows, with their new values, in result, a pointer to a slice of
+	model's type. The rows are read in the same statement that writes
+	them (with SQLite's RETURNING clause), so no other writer can change
+	them in between, as it could before a separate Find.
+
+	UpdateReturning returns the error Update would panic with, or an error
+	if result is not a pointer to a slice of model's type.
+
+	Example usage:
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Junior")
+	updates := make(Updates)
+	addUpdate(updates, "ClassYear", "Senior")
+	promoted := []User{}
+	err := db.UpdateReturning(&promoted, &User{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+*/
+func (db *DB) UpdateReturning(result interface{}, model interface{}, args DeleteOrUpdateArgs, update Updates) (err error) {
+	if err := checkReturningResult(result, model); err != nil {
+		return err
+	}
+	defer recoverError(&err)
+	db.withReturning(result).Update(model, args, update)
+	return nil
+}
+
+/*
+	DeleteReturning deletes rows as Delete does, and stores the deleted
+	rows in result, a pointer to a slice of model's type, reading them in
+	the same statement that deletes them (see UpdateReturning). Soft-deleted
+	rows are stored with their soft delete field set.
+
+	DeleteReturning returns the error Delete would panic with, or an error
+	if result is not a pointer to a slice of model's type.
+
+	Example usage:
+	filter := make(Filter)
+	addFilter(filter, "IsEnrolled", "eq", false)
+	removed := []User{}
+	err := db.DeleteReturning(&removed, &User{}, DeleteOrUpdateArgs{andFilter: filter})
+*/
+func (db *DB) DeleteReturning(result interface{}, model interface{}, args DeleteOrUpdateArgs) (err error) {
+	if err := checkReturningResult(result, model); err != nil {
+		return err
+	}
+	defer recoverError(&err)
+	db.withReturning(result).Delete(model, args)
+	return nil
+}
+
+// Checks that result is a pointer to a slice of model's type, and empties it
+func checkReturningResult(result interface{}, model interface{}) error {
+	dst := reflect.ValueOf(result)
+	if dst.Kind() != reflect.Ptr || dst.IsNil() || dst.Elem().Kind() != reflect.Slice || dst.Elem().Type().Elem() != modelType(model) {
+		return fmt.Errorf("sdorm: result must be a pointer to a slice of %v, not %T", modelType(model), result)
+	}
+	dst.Elem().Set(reflect.MakeSlice(dst.Elem().Type(), 0, 0))
+	return nil
+}
+
+// Returns a copy of db that reads the rows its writes change into dst, a
+// pointer to a model or to a slice of models
+func (db *DB) withReturning(dst interface{}) *DB {
+	returning := *db
+	returning.returning = dst
+	return &returning
+}
+
+// Runs query, a statement that changes rows, with a RETURNING clause,
+// storing the rows in db.returning and returning how many there were
+func (db *DB) queryReturning(query string, args []interface{}) (int, error) {
+	rows, err := db.inner.Query(query+" RETURNING *", args...)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	dst := reflect.ValueOf(db.returning).Elem()
+	elem := dst.Type()
+	if elem.Kind() == reflect.Slice {
+		elem = elem.Elem()
+	}
+	fields := db.modelFields(elem)
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+	values, err := scanStructs(rows, elem, fields)
+	if err != nil {
+		return 0, err
+	}
+
+	if dst.Kind() != reflect.Slice {
+		// copy only the fields read from columns, keeping the model's others
+		if len(values) > 0 {
+			for _, column := range columns {
+				if field, ok := snakeToField(fields, column); ok {
+					dst.FieldByIndex(field.index).Set(values[0].FieldByIndex(field.index))
+				}
+			}
+		}
+		return len(values), nil
+	}
+	for _, value := range values {
+		dst.Set(reflect.Append(dst, value))
+	}
+	return len(values), nil
+}
+
+// Returns a copy of db that does not read back the rows its writes
+// change, for the hooks of a write that does
+func (db *DB) withoutReturning() *DB {
+	plain := *db
+	plain.returning = nil
+	return &plain
+}
@@ -0,0 +1,97 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestReturning(t *testing.T) {
+	fmt.Println(">>> RETURNING TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Update Returning")
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Freshman")
+	updates := make(Updates)
+	addUpdate(updates, "Age", Increment(1))
+	updated := []User{{FullName: "Stale"}}
+	err := db.UpdateReturning(&updated, &User{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	helperTestEquality(t, updated, []User{
+		{FullName: "Nick", ClassYear: "Freshman", Age: 11, IsEnrolled: true},
+		{FullName: "Shannon", ClassYear: "Freshman", Age: 21},
+	})
+
+	fmt.Println("Test: Delete Returning")
+	filter = make(Filter)
+	addFilter(filter, "IsEnrolled", "eq", false)
+	deleted := []User{}
+	err = db.DeleteReturning(&deleted, &User{}, DeleteOrUpdateArgs{andFilter: filter})
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	helperTestEquality(t, deleted, []User{
+		{FullName: "Shannon", ClassYear: "Freshman", Age: 21},
+		{FullName: "Katie", ClassYear: "Sophomore", Age: 30},
+	})
+	count, _ := db.Count(&User{}, FindArgs{})
+	helperTestIntEquality(t, count, 3)
+
+	fmt.Println("Test: Errors")
+	if err = db.DeleteReturning(&[]Note{}, &User{}, DeleteOrUpdateArgs{andFilter: filter}); err == nil {
+		t.Errorf("Expected an error for a result of another type but got none")
+	}
+	if err = db.DeleteReturning(&deleted, &User{}, DeleteOrUpdateArgs{}); err != ErrMissingWhereClause {
+		t.Errorf("Expected %v but instead got %v", ErrMissingWhereClause, err)
+	}
+	updates = make(Updates)
+	addUpdate(updates, "Age", "old")
+	if err = db.UpdateReturning(&updated, &User{}, DeleteOrUpdateArgs{andFilter: filter}, updates); err == nil {
+		t.Errorf("Expected an error for a mistyped update but got none")
+	}
+}
+
+func TestCreateAndSoftDeleteReturning(t *testing.T) {
+	fmt.Println(">>> CREATE AND SOFT DELETE RETURNING TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Note{})
+
+	now := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	db.SetNowFunc(func() time.Time { return now })
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Create Returning")
+	note := Note{Body: "first"}
+	if err := db.CreateReturning(&note); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	if note.ID != 1 || note.Body != "first" {
+		t.Errorf("Expected the stored row but instead found %+v", note)
+	}
+
+	fmt.Println("Test: Soft Delete Returning")
+	deleted := []Note{}
+	err := db.DeleteReturning(&deleted, &Note{}, DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": note.ID}}})
+	if err != nil || len(deleted) != 1 || deleted[0].DeletedAt == nil || !deleted[0].DeletedAt.Equal(now) {
+		t.Errorf("Expected the row with DeletedAt %v but instead found %+v (error %v)", now, deleted, err)
+	}
+
+	fmt.Println("Test: Create Returning Keeps Other Fields")
+	createAuthorTables(conn)
+	author := Author{FullName: "Ann", Posts: []Post{{ID: 5, Title: "kept"}}}
+	if err := db.CreateReturning(&author); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	if author.ID != 1 || author.FullName != "Ann" || len(author.Posts) != 1 || author.Posts[0].Title != "kept" {
+		t.Errorf("Expected the relation to be kept but instead found %+v", author)
+	}
+}
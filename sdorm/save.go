@@ -0,0 +1,152 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+	Save writes every field of model, a pointer to a model struct, to the
+	row with the model's primary key, inserting the row if there is none.
+	A model whose primary key is still zero is inserted as by Create.
+
+	The CreatedAt field's column is left as stored and the UpdatedAt
+	field (see Create) is set to the current time, in the row and in the
+	model. The soft delete field is left to Delete and Restore.
+
+	If db tracks changes (see SetTrackChanges), only the fields that
+	changed since the model was last found, created or saved through db
+	are written, and nothing is written if none changed. Every field is
+	written otherwise, or if the row may have been written since, e.g. by
+	Update. Select and Omit narrow the fields written further.
+
+	Models with a `dorm:"version"` field are only written if their row is
+	still at the model's version, and Save returns ErrStaleObject
+	otherwise (see versionField).
+
+	Save calls the model's BeforeCreate and AfterCreate hooks when it
+	inserts a model with a zero key, and its BeforeUpdate and AfterUpdate
+	hooks otherwise (see BeforeCreateHook).
+
+	Save returns an error if the model has no primary key, fails
+	validation (see ValidationErrors), its row is stale, a hook fails or
+	a statement fails.
+
+	Example usage:
+	user := User{}
+	db.First(&user, FindArgs{})
+	user.Age++
+	err := db.Save(&user)
+*/
+func (db *DB) Save(model interface{}) error {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sdorm: Save requires a pointer to a struct, not %T", model)
+	}
+	v_model := v.Elem()
+	model_fields := db.modelFields(v_model.Type())
+	pk, ok := primaryKeyField(model_fields)
+	if !ok {
+		return fmt.Errorf("sdorm: Save requires a primary key on %v", v_model.Type())
+	}
+	tablename := db.checkTableExists(model)
+	if err := db.checkWriteFields(tablename, model_fields); err != nil {
+		return err
+	}
+
+	if v_model.FieldByIndex(pk.index).IsZero() {
+		if err := db.beforeCreate(model); err != nil {
+			return err
+		}
+		db.stampCreate(v_model, model_fields)
+		if err := validateModel(v_model, model_fields); err != nil {
+			return err
+		}
+		if err := db.insert(tablename, v_model, model_fields, false); err != nil {
+			return err
+		}
+		db.recordSnapshot(tablename, v_model, model_fields, nil)
+		return db.afterCreate(model)
+	}
+
+	if err := db.beforeUpdate(model); err != nil {
+		return err
+	}
+	if err := validateModel(v_model, model_fields); err != nil {
+		return err
+	}
+
+	// SET every column but the key, timestamps, soft delete marker and
+	// version that changed since the row was read (all if not known), and
+	// that Select and Omit allow
+	created_at, _ := createdAtField(model_fields)
+	updated_at, stamped := updatedAtField(model_fields)
+	deleted_at, _ := softDeleteField(model_fields)
+	version, versioned := versionField(model_fields)
+	changed, tracked := db.changedFields(tablename, v_model, model_fields, pk)
+	written := make([]string, 0)
+	for _, field := range model_fields {
+		switch field.name {
+		case pk.name, created_at.name, updated_at.name, deleted_at.name, version.name:
+			continue
+		}
+		if (!tracked || containsString(changed, field.name)) && db.writesField(field.name) {
+			written = append(written, field.name)
+		}
+	}
+	if tracked && len(written) == 0 {
+		// nothing to write
+		return db.afterUpdate(model)
+	}
+	if stamped {
+		v_model.FieldByIndex(updated_at.index).Set(timestampValue(updated_at, db.currentTime()))
+		written = append(written, updated_at.name)
+	}
+
+	new_fields := make([]string, 0)
+	values := make([]interface{}, 0)
+	for _, name := range written {
+		field, _ := findModelField(model_fields, name)
+		new_fields = append(new_fields, fmt.Sprintf("%v=?", field.column))
+		values = append(values, columnValue(v_model, field, model_fields))
+	}
+
+	// WHERE the row has the model's key, and is still at its version
+	filter := make(Filter)
+	addFilter(filter, pk.name, "eq", v_model.FieldByIndex(pk.index).Interface())
+	locked_filter := filter
+	if versioned {
+		new_fields = append(new_fields, versionIncrement(version))
+		locked_filter = copyFilter(filter)
+		addFilter(locked_filter, version.name, "eq", v_model.FieldByIndex(version.index).Interface())
+	}
+	where_string, where_args := db.buildWhereString(locked_filter, model_fields)
+	query := fmt.Sprintf("UPDATE %v SET %v", tablename, strings.Join(new_fields, ",")) + where_string
+
+	res, err := db.inner.Exec(query, append(values, where_args...)...)
+	if err != nil {
+		return err
+	}
+	rows_affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if versioned {
+		err = db.finishVersionedWrite(tablename, v_model, version, filter, model_fields, int(rows_affected))
+		if err != nil {
+			return err
+		}
+	}
+	if rows_affected == 0 {
+		// no row has the key yet
+		db.stampCreate(v_model, model_fields)
+		if err := db.insert(tablename, v_model, model_fields, true); err != nil {
+			return err
+		}
+		db.recordSnapshot(tablename, v_model, model_fields, nil)
+	} else {
+		db.recordSnapshot(tablename, v_model, model_fields, append(written, version.name))
+	}
+	return db.afterUpdate(model)
+}
@@ -0,0 +1,153 @@
+package sdorm
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+)
+
+/*
+	Scans every remaining row of rows into a new struct of type elem,
+	returning the structs in row order.
+
+	Result columns are matched to the given fields of elem by name
+	(see snakeToField), so the columns may come in any order. Columns
+	that match no field are ignored, and fields without a column, or
+	whose column is NULL, are left at their zero value. `dorm:"json"`
+	columns are decoded into their fields.
+
+	scanStructs returns an error if a column value cannot be stored in its field.
+*/
+func scanStructs(rows *sql.Rows, elem reflect.Type, fields []modelField) ([]reflect.Value, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	// targets stores a pointer to the "type" of each column,
+	// or nil for columns that do not match any field
+	targets := make([]*modelField, len(columns))
+	for i, column := range columns {
+		if field, ok := snakeToField(fields, column); ok {
+			targets[i] = &field
+		}
+	}
+
+	structs := []reflect.Value{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		for i, target := range targets {
+			switch {
+			case target == nil:
+				values[i] = new(interface{})
+			case isJSONField(target.field):
+				// JSON columns are read as raw text and decoded below
+				values[i] = new([]byte)
+			default:
+				// scanning into a pointer to a pointer leaves it nil for NULL
+				values[i] = reflect.New(reflect.PtrTo(target.field.Type)).Interface()
+			}
+		}
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		new_struct := reflect.New(elem).Elem()
+		for i, target := range targets {
+			if target == nil {
+				continue
+			}
+			// sets each field value in the struct
+			dst := new_struct.FieldByIndex(target.index)
+			if isJSONField(target.field) {
+				if err := unmarshalJSONField(*values[i].(*[]byte), dst); err != nil {
+					return nil, err
+				}
+			} else if value := reflect.ValueOf(values[i]).Elem(); !value.IsNil() {
+				dst.Set(value.Elem())
+			}
+		}
+		structs = append(structs, new_struct)
+	}
+	return structs, rows.Err()
+}
+
+/*
+	Scans every remaining row of rows into a map from column name to value.
+
+	Values are converted to the natural Go type for the column's declared
+	type: INTEGER to int64, REAL to float64, TEXT to string, BLOB to []byte,
+	BOOLEAN to bool and DATETIME to time.Time. NULL becomes nil.
+*/
+func scanMaps(rows *sql.Rows) ([]map[string]interface{}, error) {
+	column_types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	maps := []map[string]interface{}{}
+	for rows.Next() {
+		values := make([]interface{}, len(column_types))
+		for i := range values {
+			values[i] = new(interface{})
+		}
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]interface{}, len(column_types))
+		for i, column_type := range column_types {
+			row[column_type.Name()] = naturalValue(*values[i].(*interface{}), column_type.DatabaseTypeName())
+		}
+		maps = append(maps, row)
+	}
+	return maps, rows.Err()
+}
+
+// Converts a value read from a column declared with decltype (empty for
+// expressions) to its natural Go type, where the driver has not already
+func naturalValue(value interface{}, decltype string) interface{} {
+	decltype = strings.ToUpper(decltype)
+	switch value := value.(type) {
+	case []byte:
+		// text may come back from the driver as bytes
+		if !strings.Contains(decltype, "BLOB") {
+			return string(value)
+		}
+	case int64:
+		if decltype == "BOOL" || decltype == "BOOLEAN" {
+			return value != 0
+		}
+	}
+	return value
+}
+
+// Scans every remaining row of rows into a new value of type elem, where
+// elem is not a struct with fields (e.g. int, string or time.Time),
+// reading the first column of each row. NULL leaves the zero value.
+func scanScalars(rows *sql.Rows, elem reflect.Type) ([]reflect.Value, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	scalars := []reflect.Value{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		for i := range values {
+			values[i] = new(interface{})
+		}
+		// scanning into a pointer to a pointer leaves it nil for NULL
+		values[0] = reflect.New(reflect.PtrTo(elem)).Interface()
+		if err := rows.Scan(values...); err != nil {
+			return nil, err
+		}
+
+		scalar := reflect.New(elem).Elem()
+		if value := reflect.ValueOf(values[0]).Elem(); !value.IsNil() {
+			scalar.Set(value.Elem())
+		}
+		scalars = append(scalars, scalar)
+	}
+	return scalars, rows.Err()
+}
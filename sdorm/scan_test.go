@@ -0,0 +1,82 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScanByColumnName(t *testing.T) {
+	fmt.Println(">>> SCAN BY COLUMN NAME TESTS <<<")
+	conn := connectSQL()
+	// columns in a different order than the User struct, plus an extra column
+	_, err := conn.Exec(`create table user (
+		is_enrolled int,
+		nickname text,
+		class_year text,
+		age int,
+		full_name text
+	)`)
+	if err != nil {
+		panic(err)
+	}
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10, IsEnrolled: true}
+	user_shannon := User{FullName: "Shannon", ClassYear: "Senior", Age: 20}
+	db.Create(&user_nick)
+	db.Create(&user_shannon)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Reordered Columns")
+	results := []User{}
+	db.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_nick,
+		user_shannon,
+	})
+	if !results[0].IsEnrolled || results[1].IsEnrolled {
+		t.Errorf("Expected IsEnrolled true, false but instead found %v, %v", results[0].IsEnrolled, results[1].IsEnrolled)
+	}
+
+	fmt.Println("Test: Reordered Columns With Projection")
+	results = []User{}
+	db.Find(&results, FindArgs{projection: []interface{}{"Age", "FullName"}})
+	helperTestEquality(t, results, []User{
+		{FullName: "Nick", Age: 10},
+		{FullName: "Shannon", Age: 20},
+	})
+
+	/* ------------------------------------------------------------ */
+
+	// a model with a field that has no column in the table
+	type Student struct {
+		FullName string
+		Major    string
+	}
+	_, err = conn.Exec(`create table student (full_name text, age int)`)
+	if err != nil {
+		panic(err)
+	}
+	_, err = conn.Exec(`insert into student values ('Will', 20)`)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Test: Missing Column Left Zero")
+	students := []Student{}
+	db.Find(&students, FindArgs{})
+	if len(students) != 1 || students[0].FullName != "Will" || students[0].Major != "" {
+		t.Errorf("Expected [{Will }] but instead found %v", students)
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Missing Column in Strict Mode")
+		db.SetStrict(true)
+		defer db.SetStrict(false)
+		students = []Student{}
+		db.Find(&students, FindArgs{})
+	})
+}
@@ -0,0 +1,222 @@
+package sdorm
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+	"unicode"
+)
+
+/*
+	A column-backed field of a model struct.
+
+	Fields of embedded (anonymous) structs are flattened into their parent,
+	so a model embedding a `Timestamps` struct has one modelField per field
+	of `Timestamps`. Named struct fields tagged `dorm:"embedded"` are flattened
+	too, and their columns can be given a common prefix with
+	`dorm:"embedded;prefix:addr_"`. Structs with columns must be embedded
+	by value: embedding a pointer to one, such as *Timestamps, panics,
+	since a nil pointer leaves nowhere to store its columns.
+
+	- name: the name clients use to refer to the field in projections,
+	  filters and updates. Flattened fields keep their own name, except
+	  those of named embedded structs, which are qualified by the parent
+	  field, e.g. "Home.Street"
+	- column: the column name given by the NamingStrategy, including any
+	  prefix, or the name given with a `dorm:"column:name"` tag
+	- index: the index sequence for reflect.Value.FieldByIndex
+	- field: the underlying struct field
+	- settings: the parsed `dorm` tag of the field (see tagSettings)
+*/
+type modelField struct {
+	name     string
+	column   string
+	index    []int
+	field    reflect.StructField
+	settings map[string]string
+}
+
+/*
+	Analyzes a struct type and returns one modelField for each of its
+	columns, in struct field order, flattening embedded structs in place.
+
+	Example usage:
+	type Timestamps struct {
+		CreatedAt time.Time
+	}
+	type Address struct {
+		Street string
+	}
+	type MyStruct struct {
+		Timestamps
+		UserName string
+		Home     Address `dorm:"embedded;prefix:home_"`
+	}
+	modelFields(reflect.TypeOf(MyStruct{}), SnakeCaseNaming{}) ==> columns created_at, user_name, home_street
+*/
+func modelFields(t reflect.Type, naming NamingStrategy) []modelField {
+	return appendModelFields(nil, t, naming, nil, "", "")
+}
+
+// Returns the modelFields of a model according to db's naming strategy
+func (db *DB) modelFields(t reflect.Type) []modelField {
+	return modelFields(t, db.naming)
+}
+
+func appendModelFields(fields []modelField, t reflect.Type, naming NamingStrategy, index []int, name_prefix string, column_prefix string) []modelField {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		settings := tagSettings(field)
+		field_index := append(append([]int{}, index...), i)
+
+		// an embedded pointer may be nil, leaving nowhere to read or scan
+		// its columns, so only structs without columns may be embedded so
+		if field.Anonymous && field.Type.Kind() == reflect.Ptr && isFlattenable(field.Type.Elem(), settings) {
+			if len(appendModelFields(nil, field.Type.Elem(), naming, nil, "", "")) > 0 {
+				log.Panicf("Embedded pointer field %v of %v is not supported, embed %v instead!", field.Name, t, field.Type.Elem())
+			}
+			continue
+		}
+
+		_, embedded := settings["embedded"]
+		if (field.Anonymous || embedded) && isFlattenable(field.Type, settings) {
+			nested_name_prefix := name_prefix
+			if !field.Anonymous {
+				nested_name_prefix += field.Name + "."
+			}
+			fields = appendModelFields(fields, field.Type, naming, field_index, nested_name_prefix, column_prefix+settings["prefix"])
+			continue
+		}
+
+		if unicode.IsLower([]rune(field.Name)[0]) || isRelationField(field.Type, settings) {
+			continue
+		}
+		column := column_prefix + naming.ColumnName(field.Name)
+		if explicit, ok := settings["column"]; ok {
+			column = explicit
+		}
+		fields = append(fields, modelField{
+			name:     name_prefix + field.Name,
+			column:   column,
+			index:    field_index,
+			field:    field,
+			settings: settings,
+		})
+	}
+	return fields
+}
+
+// Checks if a struct field of type t should have its own fields flattened
+// into the parent, rather than being stored as a single column
+func isFlattenable(t reflect.Type, settings map[string]string) bool {
+	if _, ok := settings["json"]; ok {
+		return false
+	}
+	if t.Kind() != reflect.Struct || t == reflect.TypeOf(time.Time{}) {
+		return false
+	}
+	// types such as sql.NullString know how to store themselves
+	valuer := reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+	scanner := reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	return !t.Implements(valuer) && !reflect.PtrTo(t).Implements(scanner)
+}
+
+// Returns the field tagged `dorm:"primary_key"`, or false if there is none.
+// An untagged ID field is not a primary key, as Create writes it as given
+// rather than reading back the key the database assigns.
+func primaryKeyField(fields []modelField) (modelField, bool) {
+	for _, field := range fields {
+		if isPrimaryKey(field) {
+			return field, true
+		}
+	}
+	return modelField{}, false
+}
+
+// Returns the modelField called name, or false if there is none
+func findModelField(fields []modelField, name string) (modelField, bool) {
+	for _, field := range fields {
+		if field.name == name {
+			return field, true
+		}
+	}
+	return modelField{}, false
+}
+
+// Returns the column that a field name in a Filter or OrderBy refers to.
+// Names that are not fields of the model are converted by db's naming strategy
+// as-is, keeping any table qualifier, e.g. "author.FullName" ==> "author.full_name".
+func (db *DB) fieldColumn(fields []modelField, name string) string {
+	if field, ok := findModelField(fields, name); ok {
+		return field.column
+	}
+	if i := strings.Index(name, "."); i != -1 {
+		return name[:i+1] + db.naming.ColumnName(name[i+1:])
+	}
+	return db.naming.ColumnName(name)
+}
+
+/*
+	Resolves a column name in a query result back to the modelField it
+	should be scanned into. This is the inverse of the naming strategy:
+	the column is matched exactly against each field's column first, and
+	otherwise against the field names ignoring case and underscores, so
+	columns of raw or aliased queries such as "user_id" or "userid" still
+	find the field UserID, and "home_street" finds Home.Street.
+
+	Returns false if no field matches.
+*/
+func snakeToField(fields []modelField, column string) (modelField, bool) {
+	for _, field := range fields {
+		if field.column == column {
+			return field, true
+		}
+	}
+	normalized := normalizeName(column)
+	for _, field := range fields {
+		if normalizeName(field.name) == normalized {
+			return field, true
+		}
+	}
+	return modelField{}, false
+}
+
+// Lowercases a field or column name and drops its separators,
+// so that e.g. "Home.Street", "home_street" and "HomeStreet" compare equal
+func normalizeName(name string) string {
+	name = strings.ReplaceAll(name, "_", "")
+	name = strings.ReplaceAll(name, ".", "")
+	return strings.ToLower(name)
+}
+
+/*
+	Parses the `dorm` tag of a struct field into its settings.
+	Settings are separated by semicolons, and each is either a bare flag
+	or a "key:value" pair.
+
+	Example usage:
+	type MyStruct struct {
+		Home Address `dorm:"embedded;prefix:home_"`
+	}
+	tagSettings(field) ==> map[string]string{"embedded": "", "prefix": "home_"}
+*/
+func tagSettings(field reflect.StructField) map[string]string {
+	settings := make(map[string]string)
+	for _, setting := range strings.Split(field.Tag.Get("dorm"), ";") {
+		setting = strings.TrimSpace(setting)
+		if setting == "" {
+			continue
+		}
+		parts := strings.SplitN(setting, ":", 2)
+		key := strings.ToLower(strings.TrimSpace(parts[0]))
+		if len(parts) == 1 {
+			settings[key] = ""
+		} else {
+			settings[key] = strings.TrimSpace(parts[1])
+		}
+	}
+	return settings
+}
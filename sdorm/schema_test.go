@@ -0,0 +1,194 @@
+package sdorm
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func createContactTable(conn *sql.DB) {
+	_, err := conn.Exec(`create table contact (
+		id integer primary key,
+		created_by text,
+		updated_by text,
+		full_name text,
+		addr_street text,
+		addr_city text
+	)`)
+
+	if err != nil {
+		panic(err)
+	}
+}
+
+type Audit struct {
+	CreatedBy string
+	UpdatedBy string
+}
+
+type Address struct {
+	Street string
+	City   string
+}
+
+// Contact Table Schema
+type Contact struct {
+	ID int64 `dorm:"primary_key"`
+	Audit
+	FullName string
+	Home     Address `dorm:"embedded;prefix:addr_"`
+}
+
+func TestModelFields(t *testing.T) {
+	fmt.Println(">>> MODEL FIELDS TESTS <<<")
+
+	fmt.Println("Test: Flattened Column Names")
+	cols := columnNames(&Contact{})
+	expected := []interface{}{"id", "created_by", "updated_by", "full_name", "addr_street", "addr_city"}
+	if !reflect.DeepEqual(cols, expected) {
+		t.Errorf("Expected %v but instead found %v", expected, cols)
+	}
+
+	fmt.Println("Test: Flattened Field Names")
+	names := []string{}
+	for _, field := range modelFields(reflect.TypeOf(Contact{}), SnakeCaseNaming{}) {
+		names = append(names, field.name)
+	}
+	expected_names := []string{"ID", "CreatedBy", "UpdatedBy", "FullName", "Home.Street", "Home.City"}
+	if !reflect.DeepEqual(names, expected_names) {
+		t.Errorf("Expected %v but instead found %v", expected_names, names)
+	}
+
+	fmt.Println("Test: Embedded Pointer Panics")
+	type AuditedContact struct {
+		ID int64 `dorm:"primary_key"`
+		*Audit
+		FullName string
+	}
+	helperTestPanic(t, func() {
+		columnNames(&AuditedContact{})
+	})
+	db := NewDB(connectSQL())
+	defer db.Close()
+	if _, ok := db.modelRelation(reflect.TypeOf(AuditedContact{}), "Audit"); ok {
+		t.Errorf("Expected an embedded struct not to be a relation")
+	}
+
+	fmt.Println("Test: Embedded Pointer Without Columns Skipped")
+	type session struct {
+		token string
+	}
+	type SessionContact struct {
+		ID int64 `dorm:"primary_key"`
+		*session
+		FullName string
+	}
+	cols = columnNames(&SessionContact{})
+	if !reflect.DeepEqual(cols, []interface{}{"id", "full_name"}) {
+		t.Errorf("Expected [id full_name] but instead found %v", cols)
+	}
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	fmt.Println(">>> EMBEDDED STRUCT TESTS <<<")
+	conn := connectSQL()
+	createContactTable(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	contact_nick := Contact{
+		Audit:    Audit{CreatedBy: "admin"},
+		FullName: "Nick",
+		Home:     Address{Street: "Nassau St", City: "Princeton"},
+	}
+	contact_shannon := Contact{
+		Audit:    Audit{CreatedBy: "admin", UpdatedBy: "admin"},
+		FullName: "Shannon",
+		Home:     Address{Street: "Broadway", City: "New York"},
+	}
+	db.Create(&contact_nick)
+	db.Create(&contact_shannon)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Primary Keys Assigned")
+	if contact_nick.ID != 1 || contact_shannon.ID != 2 {
+		t.Errorf("Expected IDs 1 and 2 but instead found %v and %v", contact_nick.ID, contact_shannon.ID)
+	}
+
+	fmt.Println("Test: Round Trip All Contacts")
+	results := []Contact{}
+	db.Find(&results, FindArgs{})
+	if !reflect.DeepEqual(results, []Contact{contact_nick, contact_shannon}) {
+		t.Errorf("Expected %+v but instead found %+v", []Contact{contact_nick, contact_shannon}, results)
+	}
+
+	fmt.Println("Test: Project and Filter on Embedded Fields")
+	results = []Contact{}
+	filter := make(Filter)
+	addFilter(filter, "Home.City", "eq", "Princeton")
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "CreatedBy", "ASC")
+	db.Find(&results, FindArgs{
+		projection: []interface{}{"FullName", "Home.Street"},
+		andFilter:  filter,
+		orderBy:    *orderBy,
+	})
+	expected := []Contact{{FullName: "Nick", Home: Address{Street: "Nassau St"}}}
+	if !reflect.DeepEqual(results, expected) {
+		t.Errorf("Expected %+v but instead found %+v", expected, results)
+	}
+
+	fmt.Println("Test: Update Embedded Fields")
+	filter = make(Filter)
+	addFilter(filter, "FullName", "eq", "Nick")
+	updates := make(Updates)
+	addUpdate(updates, "UpdatedBy", "nick")
+	addUpdate(updates, "Home.City", "Trenton")
+	rows_updated := db.Update(&Contact{}, DeleteOrUpdateArgs{andFilter: filter}, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+
+	results = []Contact{}
+	db.Find(&results, FindArgs{andFilter: filter})
+	contact_nick.UpdatedBy = "nick"
+	contact_nick.Home.City = "Trenton"
+	if !reflect.DeepEqual(results, []Contact{contact_nick}) {
+		t.Errorf("Expected %+v but instead found %+v", []Contact{contact_nick}, results)
+	}
+}
+
+func TestSnakeToField(t *testing.T) {
+	fmt.Println(">>> SNAKE TO FIELD TESTS <<<")
+
+	type Account struct {
+		UserID     int64
+		HTTPStatus int
+		Home       Address `dorm:"embedded;prefix:addr_"`
+	}
+	fields := modelFields(reflect.TypeOf(Account{}), SnakeCaseNaming{})
+
+	tests := []struct {
+		column string
+		field  string
+	}{
+		{"user_id", "UserID"},
+		{"userid", "UserID"},
+		{"USER_ID", "UserID"},
+		{"http_status", "HTTPStatus"},
+		{"addr_street", "Home.Street"},
+		{"home_street", "Home.Street"},
+		{"unknown_column", ""},
+	}
+
+	for _, test := range tests {
+		field, ok := snakeToField(fields, test.column)
+		if test.field == "" && ok {
+			t.Errorf("snakeToField(%q): expected no field but instead found %v", test.column, field.name)
+		}
+		if test.field != "" && field.name != test.field {
+			t.Errorf("snakeToField(%q): expected %v but instead found %v", test.column, test.field, field.name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package sdorm
+
+/*
+	Type of a named, reusable query condition, such as "enrolled users"
+
+	A Scope changes the FindArgs of a query, typically by adding filters
+	to args.andFilter with addFilter, which is never nil inside a Scope.
+	Scopes are applied with db.Scopes, in Find and its variants as well
+	as in Update and Delete, which only use the filters they add.
+
+	Example usage:
+	func Enrolled(args *FindArgs) {
+		addFilter(args.andFilter, "IsEnrolled", "eq", true)
+	}
+	func InYears(years ...interface{}) Scope {
+		return func(args *FindArgs) {
+			addFilter(args.andFilter, "ClassYear", "in", years)
+		}
+	}
+	db.Scopes(Enrolled, InYears("Junior", "Senior")).Find(&results, FindArgs{})
+*/
+type Scope func(args *FindArgs)
+
+// Scopes returns a copy of db that applies the given scopes, after any
+// db already applies, to every query it runs. db itself is unchanged.
+func (db *DB) Scopes(scopes ...Scope) *DB {
+	scoped := *db
+	scoped.scopes = append(append([]Scope{}, db.scopes...), scopes...)
+	return &scoped
+}
+
+// Returns a copy of db that applies no scopes, for queries it runs on
+// behalf of a scoped query, such as loading associations
+func (db *DB) withoutScopes() *DB {
+	unscoped := *db
+	unscoped.scopes = nil
+	return &unscoped
+}
+
+// Returns args with db's scopes applied. The filter, ordering and
+// projection of args are copied first, so the caller's are unchanged.
+func (db *DB) applyScopes(args FindArgs) FindArgs {
+	if len(db.scopes) == 0 {
+		return args
+	}
+	args.andFilter = copyFilter(args.andFilter)
+	args.orderBy = append(OrderBy{}, args.orderBy...)
+	args.projection = append([]interface{}{}, args.projection...)
+
+	for _, scope := range db.scopes {
+		scope(&args)
+	}
+	return args
+}
+
+// Returns the filter of a Delete or Update with db's scopes applied
+func (db *DB) scopedFilter(filter Filter) Filter {
+	return db.applyScopes(FindArgs{andFilter: filter}).andFilter
+}
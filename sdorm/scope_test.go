@@ -0,0 +1,118 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func enrolledScope(args *FindArgs) {
+	addFilter(args.andFilter, "IsEnrolled", "eq", true)
+}
+
+func inYearsScope(years ...interface{}) Scope {
+	return func(args *FindArgs) {
+		addFilter(args.andFilter, "ClassYear", "in", years)
+	}
+}
+
+func oldestFirstScope(args *FindArgs) {
+	addOrder(&args.orderBy, "Age", "DESC")
+}
+
+func TestScopes(t *testing.T) {
+	fmt.Println(">>> SCOPE TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10, IsEnrolled: true}
+	user_shannon := User{FullName: "Shannon", ClassYear: "Freshman", Age: 20, IsEnrolled: false}
+	user_will := User{FullName: "Will", ClassYear: "Senior", Age: 20, IsEnrolled: true}
+	user_katie := User{FullName: "Katie", ClassYear: "Sophomore", Age: 30, IsEnrolled: false}
+	user_albert := User{FullName: "Albert", ClassYear: "Senior", Age: 40, IsEnrolled: true}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Find Enrolled")
+	results := []User{}
+	db.Scopes(enrolledScope).Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_nick,
+		user_will,
+		user_albert,
+	})
+
+	fmt.Println("Test: Composed Scopes With Own Filter")
+	filter := make(Filter)
+	addFilter(filter, "Age", "lt", 40)
+	results = []User{}
+	db.Scopes(enrolledScope, inYearsScope("Senior", "Sophomore")).Find(&results, FindArgs{andFilter: filter})
+	helperTestEquality(t, results, []User{
+		user_will,
+	})
+	if len(filter) != 1 {
+		t.Errorf("Expected scopes to leave the caller's filter unchanged but found %v", filter)
+	}
+
+	fmt.Println("Test: Chained Scopes With Ordering")
+	scoped := db.Scopes(inYearsScope("Freshman", "Sophomore"))
+	results = []User{}
+	scoped.Scopes(oldestFirstScope).Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_katie,
+		user_shannon,
+		user_nick,
+	})
+	results = []User{}
+	scoped.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_nick,
+		user_shannon,
+		user_katie,
+	})
+
+	fmt.Println("Test: Update Enrolled Seniors")
+	updates := make(Updates)
+	addUpdate(updates, "Age", 50)
+	rows_updated := db.Scopes(enrolledScope, inYearsScope("Senior")).Update(&User{}, DeleteOrUpdateArgs{}, updates)
+	helperTestIntEquality(t, rows_updated, 2)
+
+	fmt.Println("Test: Delete Enrolled Freshmen")
+	rows_deleted := db.Scopes(enrolledScope).Delete(&User{}, DeleteOrUpdateArgs{andFilter: Filter{"ClassYear": FilterArg{"eq": "Freshman"}}})
+	helperTestIntEquality(t, rows_deleted, 1)
+
+	results = []User{}
+	db.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_shannon,
+		{FullName: "Will", ClassYear: "Senior", Age: 50, IsEnrolled: true},
+		user_katie,
+		{FullName: "Albert", ClassYear: "Senior", Age: 50, IsEnrolled: true},
+	})
+}
+
+func TestScopesWithPreload(t *testing.T) {
+	fmt.Println(">>> SCOPE PRELOAD TESTS <<<")
+	conn := connectSQL()
+	createAuthorTables(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	author_nick := Author{FullName: "Nick"}
+	author_shannon := Author{FullName: "Shannon"}
+	db.Create(&author_nick)
+	db.Create(&author_shannon)
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Intro to ORMs"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Scopes Do Not Apply to Preloaded Posts")
+	named_nick := func(args *FindArgs) {
+		addFilter(args.andFilter, "FullName", "eq", "Nick")
+	}
+	authors := []Author{}
+	db.Scopes(named_nick).Find(&authors, FindArgs{}.Preload("Posts"))
+	if len(authors) != 1 || len(authors[0].Posts) != 1 || authors[0].Posts[0].Title != "Intro to ORMs" {
+		t.Errorf("Expected Nick with one post but instead found %+v", authors)
+	}
+}
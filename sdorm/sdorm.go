@@ -2,34 +2,62 @@ package sdorm
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"reflect"
-	"regexp"
+	"sort"
 	"strings"
+	"time"
 	"unicode"
 )
 
 // DB handle
 type DB struct {
-	inner *sql.DB
+	conn         *sql.DB
+	inner        executor
+	naming       NamingStrategy
+	strict       bool
+	scopes       []Scope
+	unscoped     bool
+	allowGlobal  bool
+	returning    interface{}
+	now          func() time.Time
+	selects      []string
+	omits        []string
+	snapshots    *snapshotStore
+	trackChanges bool
+	tables       *tableCache
 }
 
 // NewDB returns a new DB using the provided `conn`, a sql database
-// connection.
+// connection. Tables and columns are named with SnakeCaseNaming
+// until SetNamingStrategy is called.
 func NewDB(conn *sql.DB) DB {
-	return DB{inner: conn}
+	return DB{conn: conn, inner: conn, naming: SnakeCaseNaming{}, snapshots: &snapshotStore{}, tables: &tableCache{}}
+}
+
+// SetStrict enables or disables strict mode. In strict mode, Find panics
+// if a column for one of the model's fields is missing from the table,
+// rather than leaving that field at its zero value.
+func (db *DB) SetStrict(strict bool) {
+	db.strict = strict
 }
 
 // Closes db's database connection.
 func (db *DB) Close() error {
-	return db.inner.Close()
+	return db.conn.Close()
 }
 
 /*
 	TableName analyzes a struct, v, and returns a single string, equal
 	to the name of that struct's type, converted to underscore_case.
 	Refer to the specification of underscore_case, below.
+	Pointers and slices are looked through, so TableName(&[]MyStruct{})
+	is also "my_struct". Models implementing TableNamer choose their own
+	name, and TableName panics for unnamed types such as struct{}.
+	TableName always uses the default SnakeCaseNaming; the names a DB
+	uses follow its own NamingStrategy.
 	Example usage:
 	type MyStruct struct {
 	...
@@ -37,9 +65,7 @@ func (db *DB) Close() error {
 	TableName(&MyStruct{}) ==> "my_struct"
 */
 func TableName(result interface{}) string {
-	table_name := reflect.TypeOf(result).String()
-	table_name = strings.Split(table_name, ".")[1]
-	return camelToSnake(table_name)
+	return tableNameWith(result, SnakeCaseNaming{})
 }
 
 /*
@@ -50,11 +76,24 @@ func TableName(result interface{}) string {
 
 	Valid operator codes are: "lt" for less than, "gt" for greater than, "leq" for
 	less than or equal to, "geq" for greater than or equal to, "eq" for equal to,
-	"neq" for not equal to, "in" for in a set of values, and "nin" for not in a set of values.
+	"neq" for not equal to, "in" for in a set of values, "nin" for not in a set of values,
+	"like" for matching a SQL LIKE pattern such as "%word%", and "exists" or "nexists"
+	for whether a sub-query returns any rows.
+
+	Instead of a literal, the field value may be a SubQuery, compared as a single
+	value or, for "in" and "nin", as a set of values, or a ColumnRef naming
+	another column. "exists" and "nexists" take a SubQuery and do not use the
+	column name, which only needs to tell the conditions of a Filter apart.
+	See the comment above SubQuery for example usage.
 
 	For all operators excluding "in" and "nin", the field value should only be a single value.
+	A nil value with "eq" or "neq" matches columns that are (or are not) NULL.
 	For "in" and "nin", the field value should be an array of values.
 
+	Fields tagged `dorm:"json"` can be filtered on a path inside the stored JSON
+	document by writing the column name as "Field->path", e.g. "Settings->theme"
+	or "Tags->[0]". The path is evaluated with SQLite's json_extract.
+
 	See the comment above addFilter for example usage.
 */
 type FilterArg map[string]interface{}
@@ -67,6 +106,7 @@ type Filter map[string]FilterArg
 	filter := make(Filter)
 	addFilter(filter, "Name", "eq", "Nick")
 	addFilter(filter, "FullName", "in", []interface{}{"Nick", "Will"})
+	addFilter(filter, "Settings->theme", "eq", "dark")
 	findArgs.andFilter = filter
 */
 func addFilter(filter Filter, field string, operator string, value interface{}) {
@@ -125,6 +165,7 @@ type Updates map[string]interface{}
 	updates := make(Updates)
 	addUpdate(updates, "FullName", "Katie")
 	addUpdate(updates, "Age", 15)
+	addUpdate(updates, "Age", Increment(1))
 */
 func addUpdate(updates Updates, field string, value interface{}) {
 	updates[field] = value
@@ -136,12 +177,20 @@ func addUpdate(updates Updates, field string, value interface{}) {
 	- andFilter: a Filter data type (see definition of Filter for more info)
 	- orderBy: an OrderBy data type (see definition of OrderBy for more info)
 	- limit: a positive int capping the number of returned rows
+	- preload: names of relationship fields to load along with the rows
+	  (see the comment above Preload for more info)
+	- joins: a Joins data type (see definition of Join for more info)
+	- from: a model whose table the rows are read from, when it differs
+	  from the type of the result, e.g. when joining into a composite struct
 */
 type FindArgs struct {
 	projection []interface{}
 	andFilter  Filter
 	orderBy    OrderBy
 	limit      int
+	preload    []string
+	joins      Joins
+	from       interface{}
 }
 
 /*
@@ -152,8 +201,16 @@ type FindArgs struct {
 
 	The argument `result` will be a pointer to an empty slice of models.
 
+	Find selects the columns of the model's fields (or of the projection)
+	by name and matches the result columns back to fields by name, so
+	the order of columns in the table does not need to match the order of
+	fields in the struct. Fields whose column is missing from the table
+	are left at their zero value, unless strict mode is enabled with
+	SetStrict, in which case Find panics.
+
 	Find panics if the generated SQL query string is invalid, or if the
-	table does not exist.
+	table does not exist. It panics with an UnknownFieldError, before
+	running any query, if args name a field the model does not have.
 
 	Example usage to find UserComment entries in the database:
 	type UserComment struct = { ... }
@@ -170,120 +227,150 @@ type FindArgs struct {
 	db.Find(&result, args)
 */
 func (db *DB) Find(result interface{}, args FindArgs) {
+	args = db.applyScopes(args)
+
 	// get struct type (e.g. dorm.User)
 	elem := reflect.TypeOf(result).Elem().Elem()
+	base := result
+	if args.from != nil {
+		base = args.from
+	}
+	query, where_args := db.buildFindQuery(elem, base, args)
 
-	// create a new struct of the same type
-	res := reflect.New(elem)
-	val := res.Elem()
-	j := 0
+	// execute query
+	rows, err := db.inner.Query(query, where_args...)
 
-	// fix order of args.projection to match order of fields in struct
-	ordered_projection := make([]interface{}, len(args.projection))
-	if len(args.projection) > 0 {
-		for i := 0; i < val.NumField(); i++ {
-			if !stringInSlice(val.Type().Field(i).Name, args.projection) {
-				continue
-			}
-			ordered_projection[j] = val.Type().Field(i).Name
-			j++
-		}
-	}
-	if j != len(ordered_projection) {
-		log.Panic("Invalid projection column provided!")
+	// invalid query results in nil rows
+	if err != nil {
+		log.Panic("Invalid database query provided!")
 	}
+	defer rows.Close()
 
-	// insert placeholders for projected columns
-	projected_columns := "*"
-	if len(ordered_projection) > 0 {
-		projected_placeholders := make([]string, len(ordered_projection))
-		for i := range ordered_projection {
-			projected_placeholders[i] = "%v"
+	// modify original result
+	arr := reflect.ValueOf(result).Elem()
+	start := arr.Len()
+	new_structs, err := scanStructs(rows, elem, db.modelFields(elem))
+	if err != nil {
+		log.Panic(err)
+	}
+	for _, new_struct := range new_structs {
+		// append new struct to array
+		arr.Set(reflect.Append(arr, new_struct))
+	}
+	rows.Close()
+
+	// remember the stored values of models, so Save can tell what changed
+	if db.trackChanges && elem == modelType(base) {
+		tablename := db.tableName(base)
+		model_fields := db.modelFields(elem)
+		var names []string
+		for _, name := range args.projection {
+			names = append(names, fmt.Sprint(name))
+		}
+		for i := start; i < arr.Len(); i++ {
+			db.recordSnapshot(tablename, arr.Index(i), model_fields, names)
 		}
-		projected_columns = strings.Join(projected_placeholders, ", ")
 	}
 
-	// add PROJECTED columns to query
-	query := fmt.Sprintf("SELECT %v FROM %v", projected_columns, TableName(result))
-
-	// convert each column name to camel case
-	snake_projection := make([]interface{}, len(ordered_projection))
-	for i := 0; i < len(ordered_projection); i++ {
-		snake_projection[i] = camelToSnake(ordered_projection[i].(string))
+	// load associations of the new structs, one query each
+	for _, name := range args.preload {
+		db.withoutScopes().preload(arr.Slice(start, arr.Len()), name)
 	}
 
-	// construct query with projected columns
-	query = fmt.Sprintf(query, snake_projection...)
+	for i := start; i < arr.Len(); i++ {
+		if err := db.afterFind(arr.Index(i).Addr().Interface()); err != nil {
+			panic(err)
+		}
+	}
+}
 
-	// add WHERE filters if necessary
-	query += buildWhereString(args.andFilter)
+// Builds the SELECT query for Find, reading the rows of base's table (and any
+// joined tables) into structs of type elem, along with the values bound to
+// its "?" placeholders, in order
+func (db *DB) buildFindQuery(elem reflect.Type, base interface{}, args FindArgs) (string, []interface{}) {
+	model_fields := db.modelFields(elem)
+	tablename := db.tableName(base)
 
-	// add ORDER BY
-	if len(args.orderBy) > 0 {
-		orderByFields := make([]string, 0)
-		for _, orderField := range args.orderBy {
-			orderByFields = append(orderByFields, camelToSnake(orderField[0])+" "+orderField[1])
+	// fix order of args.projection to match order of fields in struct
+	ordered_projection := make([]modelField, 0, len(args.projection))
+	for _, field := range model_fields {
+		if len(args.projection) > 0 && !stringInSlice(field.name, args.projection) {
+			continue
 		}
-		query += " ORDER BY " + strings.Join(orderByFields, ", ")
+		ordered_projection = append(ordered_projection, field)
 	}
-
-	// add row LIMIT
-	// ignore LIMIT value if invalid
-	if args.limit > 0 {
-		query += fmt.Sprintf(" LIMIT %d", args.limit)
+	if len(args.projection) > 0 && len(ordered_projection) != len(args.projection) {
+		names := []string{}
+		for _, field := range model_fields {
+			names = append(names, field.name)
+		}
+		for _, name := range args.projection {
+			if !containsString(names, fmt.Sprint(name)) {
+				panic(&UnknownFieldError{Field: fmt.Sprint(name), Clause: "projection", Table: tablename, Suggestion: closestName(fmt.Sprint(name), names)})
+			}
+		}
 	}
 
-	// execute query
-	rows, _ := db.inner.Query(query)
+	// look up the columns of every table read
+	tables := []string{tablename}
+	for _, join := range args.joins {
+		tables = append(tables, db.tableName(join.model))
+	}
+	table_columns := make(map[string][]string)
+	for _, table := range tables {
+		table_columns[table] = db.tableColumns(table)
+		if len(table_columns[table]) == 0 {
+			log.Panicf("Table %v not found!", table)
+		}
+	}
 
-	defer rows.Close()
+	// add PROJECTED columns that exist in the tables to query
+	snake_projection := db.selectList(ordered_projection, tables, table_columns)
+	query := fmt.Sprintf("SELECT %v FROM %v", strings.Join(snake_projection, ", "), tablename)
 
-	// invalid query results in nil rows
-	if rows == nil {
-		log.Panic("Invalid database query provided!")
+	// add JOINs, allowing any table's columns to be referred to by table
+	if len(args.joins) > 0 {
+		join_models := []interface{}{base}
+		for _, join := range args.joins {
+			join_models = append(join_models, join.model)
+		}
+		model_fields = append(db.qualifiedFields(join_models...), model_fields...)
+		for _, join := range args.joins {
+			query += db.joinClause(join, model_fields)
+		}
 	}
 
-	// store column names
-	cols := columnNames(res.Interface())
-	// replace column names with projection if necessary
-	if len(ordered_projection) > 0 {
-		cols = snake_projection
+	if err := db.checkQueryFields(tablename, model_fields, args.andFilter, args.orderBy); err != nil {
+		panic(err)
 	}
 
-	// stores list of column types
-	fields := make([]interface{}, len(cols))
+	// add WHERE filters if necessary, skipping soft-deleted rows
+	where_string, where_args := db.buildWhereString(db.softDeleteFilter(base, args.andFilter, len(args.joins) > 0), model_fields)
+	query += where_string
 
-	// fields array stores a pointer to the "type" of each column
-	j = 0
-	for i := 0; i < val.NumField(); i++ {
-		// if we have a projection, but the current field is not in the project, skip
-		if len(ordered_projection) > 0 && !stringInSlice(val.Type().Field(i).Name, ordered_projection) {
-			continue
+	// add ORDER BY and row LIMIT
+	query += db.buildOrderLimitString(args, model_fields)
+
+	return query, where_args
+}
+
+// Given FindArgs on a model with the given fields, build the ORDER BY and
+// LIMIT portions of a SQL query. Returns empty string if neither is specified
+func (db *DB) buildOrderLimitString(args FindArgs, fields []modelField) string {
+	query := ""
+	if len(args.orderBy) > 0 {
+		orderByFields := make([]string, 0)
+		for _, orderField := range args.orderBy {
+			orderByFields = append(orderByFields, db.fieldColumn(fields, orderField[0])+" "+orderField[1])
 		}
-		field := reflect.New(val.Field(i).Type()).Interface()
-		fields[j] = field
-		j++
+		query += " ORDER BY " + strings.Join(orderByFields, ", ")
 	}
 
-	// modify original result
-	arr := reflect.ValueOf(result).Elem()
-	for rows.Next() {
-		new_struct := reflect.New(elem).Elem()
-		// stores each row's values into the fields array (temporarily)
-		rows.Scan(fields...)
-		j := 0
-		for i := 0; i < val.NumField(); i++ {
-			// if we have a projection, but the current field is not in the project, skip
-			if len(ordered_projection) > 0 && !stringInSlice(val.Type().Field(i).Name, ordered_projection) {
-				continue
-			}
-			// sets each field value in the struct
-			new_struct.Field(i).Set(reflect.ValueOf(fields[j]).Elem())
-			j++
-		}
-		// append new struct to array
-		arr.Set(reflect.Append(arr, new_struct))
+	// ignore LIMIT value if invalid
+	if args.limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", args.limit)
 	}
+	return query
 }
 
 /*
@@ -291,6 +378,16 @@ func (db *DB) Find(result interface{}, args FindArgs) {
 	The table for the model *must* already exist, and Create() panics
 	if it does not.
 
+	Fields annotated with the tag `dorm:"json"` (typically structs, maps
+	or slices) are marshaled to JSON and stored in a TEXT column.
+
+	Create panics with ValidationErrors, inserting nothing, if fields fail
+	the rules of their `dorm:"validate:..."` tags (see ValidationErrors).
+
+	CreatedAt and UpdatedAt fields (or fields tagged `dorm:"autocreatetime"`
+	and `dorm:"autoupdatetime"`) that are still zero are set to the current
+	time (see SetNowFunc), in the row and in the model.
+
 	Optionally, at most one of the fields of the provided `model`
 	might be annotated with the tag `dorm:"primary_key"`. If such a
 	field exists, Create() should ignore the provided value of that
@@ -301,55 +398,75 @@ func (db *DB) Find(result interface{}, args FindArgs) {
 func (db *DB) Create(model interface{}) {
 	tablename := db.checkTableExists(model)
 
-	elem := reflect.TypeOf(model).Elem()
-	res := reflect.New(elem)
+	if err := db.beforeCreate(model); err != nil {
+		panic(err)
+	}
+	v_model := reflect.ValueOf(model).Elem()
+	model_fields := db.modelFields(v_model.Type())
+	db.stampCreate(v_model, model_fields)
+	if err := validateModel(v_model, model_fields); err != nil {
+		panic(err)
+	}
+	if err := db.insert(tablename, v_model, model_fields, false); err != nil {
+		log.Panic(err)
+	}
+	db.recordSnapshot(tablename, v_model, model_fields, nil)
+	if err := db.afterCreate(model); err != nil {
+		panic(err)
+	}
+}
 
+// Inserts the model v_model into tablename. Unless with_key is set, a
+// tagged primary key is left for SQLite to assign and read back into v_model
+func (db *DB) insert(tablename string, v_model reflect.Value, model_fields []modelField, with_key bool) error {
 	cols := []string{}
 	placeholder := []string{}
 	fields := []interface{}{}
 
-	v := reflect.ValueOf(res.Interface()).Elem()
-	v_model := reflect.ValueOf(model).Elem()
-	for i := 0; i < v.NumField(); i++ {
-		colname := v.Type().Field(i).Name
-		tag := v.Type().Field(i).Tag
-		if tag == `dorm:"primary_key"` {
+	for _, field := range model_fields {
+		if isPrimaryKey(field) && !with_key {
 			// ignore PK column
 			continue
 		}
-		if unicode.IsLower([]rune(colname)[0]) {
-			continue
-		}
-		colname_fixed := camelToSnake(colname)
-		cols = append(cols, colname_fixed)
+		cols = append(cols, field.column)
 
 		placeholder = append(placeholder, "?")
-		fields = append(fields, v_model.Field(i).Interface())
+		fields = append(fields, columnValue(v_model, field, model_fields))
 	}
 
 	query := fmt.Sprintf("INSERT or REPLACE INTO %v(%v) VALUES(%v)", tablename, strings.Join(cols, ","), strings.Join(placeholder, ","))
+	if db.returning != nil {
+		// the row read back sets the key along with every other field
+		_, err := db.queryReturning(query, fields)
+		return err
+	}
 
 	insert_res, err := db.inner.Exec(query, fields...)
 	if err != nil {
-		log.Panic(err)
+		return err
 	}
 
-	the_struct := reflect.ValueOf(model).Elem() // gets values in model struct
-	new_struct := reflect.New(elem).Elem()      // creates new struct with same type as model
-	v_model = reflect.ValueOf(model).Elem()
-	for i := 0; i < v.NumField(); i++ {
-		tag := v.Type().Field(i).Tag
-		if tag == `dorm:"primary_key"` {
+	for _, field := range model_fields {
+		if isPrimaryKey(field) && !with_key {
 			// if PK tag, then update PK column with last insert ID
 			id, _ := insert_res.LastInsertId()
-			new_struct.Field(i).Set(reflect.ValueOf(&id).Elem()) // set id in struct
-		} else {
-			// otherwise, set field to itself
-			new_struct.Field(i).Set(v_model.Field(i))
+			v_model.FieldByIndex(field.index).SetInt(id) // set id in struct
 		}
 	}
-	the_struct.Set(new_struct)
+	return nil
+}
 
+// Returns the value stored in the column of field for the model v_model
+func columnValue(v_model reflect.Value, field modelField, model_fields []modelField) interface{} {
+	value := v_model.FieldByIndex(field.index)
+	if isSoftDeleteField(model_fields, field) && value.IsZero() {
+		// rows start out not deleted
+		return nil
+	}
+	if isJSONField(field.field) {
+		return marshalJSONField(value.Interface())
+	}
+	return value.Interface()
 }
 
 /*
@@ -362,9 +479,17 @@ func (db *DB) Create(model interface{}) {
 	Args in the form of DeleteOrUpdateArgs can be provided (see the comment
 	above the DeleteOrUpdateArgs type definition for more details).
 
+	Models with a soft delete field (see Unscoped) are soft-deleted: the
+	rows are marked deleted rather than removed.
+
 	Delete panics if the generated SQL query string is invalid, or if the
 	table does not exist.
 
+	Delete panics with ErrMissingWhereClause, deleting nothing, if args
+	and db's scopes have no filter, unless db allows it (see AllowGlobal).
+
+	DeleteReturning also returns the rows deleted.
+
 	Example usage to delete some UserComment entries in the database:
 	type UserComment struct = { ... }
 	model := []UserComment{}
@@ -377,22 +502,34 @@ func (db *DB) Create(model interface{}) {
 */
 func (db *DB) Delete(model interface{}, args DeleteOrUpdateArgs) int {
 	tablename := db.checkTableExists(model)
-	query := fmt.Sprintf("DELETE FROM %v", tablename)
+	model_fields := db.modelFields(modelType(model))
+	if err := db.checkQueryFields(tablename, model_fields, db.scopedFilter(args.andFilter), nil); err != nil {
+		panic(err)
+	}
+	if err := db.checkGlobal(db.scopedFilter(args.andFilter)); err != nil {
+		panic(err)
+	}
+	if err := db.beforeDelete(model); err != nil {
+		panic(err)
+	}
 
-	// add WHERE filters if necessary
-	query += buildWhereString(args.andFilter)
+	rows_affected := 0
+	if deleted_at, ok := softDeleteField(model_fields); ok && !db.unscoped {
+		rows_affected = db.softDelete(model, tablename, deleted_at, args)
+	} else {
+		query := fmt.Sprintf("DELETE FROM %v", tablename)
 
-	delete_res, err := db.inner.Exec(query)
-	if err != nil {
-		log.Panic(err)
-	}
+		// add WHERE filters if necessary
+		where_string, where_args := db.buildWhereString(db.scopedFilter(args.andFilter), model_fields)
+		query += where_string
 
-	rows_affected, err := delete_res.RowsAffected()
-	if err != nil {
-		log.Panic(err)
+		rows_affected = db.execRowsAffected(tablename, query, where_args)
 	}
 
-	return int(rows_affected)
+	if err := db.afterDelete(model); err != nil {
+		panic(err)
+	}
+	return rows_affected
 }
 
 /*
@@ -410,7 +547,33 @@ func (db *DB) Delete(model interface{}, args DeleteOrUpdateArgs) int {
 
 	Update panics if the generated SQL query string is invalid, if the
 	table does not exist, or if a passed-in datatype in the Update parameter
-	does not match its type in the SQL db.
+	does not match its type in the SQL db. Values of other types are
+	converted when no information is lost, e.g. int64(3) for an int field
+	or a string for a field of a named string type (see coerceValue).
+	An Expression value, such as Increment(1), is computed by the database. New values for `dorm:"json"`
+	fields are marshaled to JSON, just as in Create.
+
+	The UpdatedAt field's column, if the model has one (see Create), is set
+	to the current time unless `update` sets it.
+
+	Update panics with an UnknownFieldError if `update` or the filter
+	names a field the model does not have.
+
+	Update panics with ErrMissingWhereClause, updating nothing, if args
+	and db's scopes have no filter, unless db allows it (see AllowGlobal).
+
+	Update panics with ValidationErrors, updating nothing, if new values
+	fail the rules of their fields' `dorm:"validate:..."` tags.
+
+	For models with a `dorm:"version"` field, the version of every row
+	updated is incremented. If `model` has a non-zero primary key, only
+	rows still at the version of `model` are updated, and Update panics
+	with ErrStaleObject if other rows match (see versionField). An empty
+	model locks nothing, so bulk updates of versioned rows overwrite
+	changes made since they were read; to lock them, set the version in
+	the filter.
+
+	UpdateReturning also returns the rows updated.
 
 	Example usage to update some UserComment entries in the database:
 	type UserComment struct = { ... }
@@ -424,37 +587,119 @@ func (db *DB) Delete(model interface{}, args DeleteOrUpdateArgs) int {
 */
 func (db *DB) Update(model interface{}, args DeleteOrUpdateArgs, update Updates) int {
 	tablename := db.checkTableExists(model)
+	model_fields := db.modelFields(modelType(model))
+	if err := db.checkUpdateFields(tablename, model_fields, db.scopedFilter(args.andFilter), update); err != nil {
+		panic(err)
+	}
+	if err := db.checkGlobal(db.scopedFilter(args.andFilter)); err != nil {
+		panic(err)
+	}
+	if err := db.beforeUpdate(model); err != nil {
+		panic(err)
+	}
 	query := fmt.Sprintf("UPDATE %v", tablename)
 
 	new_fields := make([]string, 0)
+	values := make([]interface{}, 0)
+	coerced := make(Updates, len(update))
 	for field := range update {
-		// verify that types match those in model
-		expected_type := reflect.ValueOf(model).Elem().FieldByName(field).Type()
-		actual_type := reflect.TypeOf(update[field])
-		if expected_type != actual_type {
-			log.Panicf("Type of field %v in Update is %v but should be %v!", field, actual_type, expected_type)
+		model_field, _ := findModelField(model_fields, field)
+
+		// construct COL=EXPRESSION for values computed by the database
+		if expr, ok := update[field].(Expression); ok {
+			set_clause, set_args := expr.setClause(model_field)
+			new_fields = append(new_fields, set_clause)
+			values = append(values, set_args...)
+			continue
 		}
 
-		// construct COL=NEW_VAL in query string
-		new_field := fmt.Sprintf("%v=%v", camelToSnake(field), update[field])
-		if reflect.TypeOf(update[field]) == reflect.TypeOf("") {
-			new_field = fmt.Sprintf("%v='%v'", camelToSnake(field), update[field])
+		// verify that types match those in model, or convert to them
+		expected_type := model_field.field.Type
+		value, ok := coerceValue(update[field], expected_type)
+		if !ok {
+			log.Panicf("Type of field %v in Update is %v but should be %v!", field, reflect.TypeOf(update[field]), expected_type)
 		}
-		new_fields = append(new_fields, new_field)
+		coerced[field] = value.Interface()
+
+		// construct COL=? in query string
+		new_fields = append(new_fields, fmt.Sprintf("%v=?", model_field.column))
+		if isJSONField(model_field.field) {
+			values = append(values, marshalJSONField(coerced[field]))
+		} else {
+			values = append(values, coerced[field])
+		}
+	}
+
+	if err := validateUpdates(model_fields, coerced); err != nil {
+		panic(err)
+	}
+
+	// bump the time rows were last written, unless set explicitly
+	if updated_at, ok := updatedAtField(model_fields); ok {
+		if _, ok := update[updated_at.name]; !ok {
+			new_fields = append(new_fields, fmt.Sprintf("%v=?", updated_at.column))
+			values = append(values, timestampValue(updated_at, db.currentTime()).Interface())
+		}
+	}
+
+	// increment the version of versioned rows, unless set explicitly, and
+	// if model identifies a row, only write it if still at model's version
+	filter := db.softDeleteFilter(model, db.scopedFilter(args.andFilter), false)
+	locked_filter := filter
+	v_model := reflect.ValueOf(model).Elem()
+	version, versioned := versionField(model_fields)
+	if _, ok := update[version.name]; ok {
+		versioned = false
+	}
+	locked := versioned && identifiesRow(v_model, model_fields)
+	if versioned {
+		new_fields = append(new_fields, versionIncrement(version))
+	}
+	if locked {
+		locked_filter = copyFilter(filter)
+		addFilter(locked_filter, version.name, "eq", v_model.FieldByIndex(version.index).Interface())
 	}
 
 	// SET COL1=NEW_VAL1, COL2=NEW_VAL2...
 	query += " SET " + strings.Join(new_fields, ",")
 
-	// add WHERE filters if necessary
-	query += buildWhereString(args.andFilter)
+	// add WHERE filters if necessary, skipping soft-deleted rows
+	where_string, where_args := db.buildWhereString(locked_filter, model_fields)
+	query += where_string
+
+	rows_affected := db.execRowsAffected(tablename, query, append(values, where_args...))
+	if locked {
+		if err := db.finishVersionedWrite(tablename, v_model, version, filter, model_fields, rows_affected); err != nil {
+			panic(err)
+		}
+	}
+
+	if err := db.afterUpdate(model); err != nil {
+		panic(err)
+	}
+	return rows_affected
+}
 
-	update_res, err := db.inner.Exec(query)
+// Executes a statement that changes rows of tablename, returning the number
+// of rows affected. The rows are also scanned into db.returning, if set.
+// Snapshots of the table's rows (see SetTrackChanges) are dropped, since
+// any of them may have changed
+func (db *DB) execRowsAffected(tablename string, query string, args []interface{}) int {
+	defer db.snapshots.clearTable(tablename)
+	if db.returning != nil {
+		rows_affected, err := db.queryReturning(query, args)
+		if err != nil {
+			log.Panic(err)
+		}
+		return rows_affected
+	}
+
+	res, err := db.inner.Exec(query, args...)
 	if err != nil {
 		log.Panic(err)
 	}
 
-	rows_affected, err := update_res.RowsAffected()
+	rows_affected, err := res.RowsAffected()
 	if err != nil {
 		log.Panic(err)
 	}
@@ -466,16 +711,31 @@ func (db *DB) Update(model interface{}, args DeleteOrUpdateArgs, update Updates)
 /* HELPER METHODS                                               */
 /* ------------------------------------------------------------ */
 
-// Given a Filter, build the WHERE portion of a SQL query
+// Given a Filter on a model with the given fields, build the WHERE portion
+// of a SQL query along with the values bound to its "?" placeholders, in order.
 // Returns empty string if no filter specified
-func buildWhereString(andFilter Filter) string {
+func (db *DB) buildWhereString(andFilter Filter, fields []modelField) (string, []interface{}) {
 	whereString := ""
+	whereArgs := make([]interface{}, 0)
 	if len(andFilter) > 0 {
-		// an array of "field_name operator value"
-		filters := make([]string, 0)
+		// visit fields in a fixed order so the placeholders line up with whereArgs
+		field_names := make([]string, 0, len(andFilter))
 		for field_name := range andFilter {
+			field_names = append(field_names, field_name)
+		}
+		sort.Strings(field_names)
+
+		// an array of "field_name operator ?"
+		filters := make([]string, 0)
+		for _, field_name := range field_names {
 			fields_filters := andFilter[field_name]
+			field_operators := make([]string, 0, len(fields_filters))
 			for field_operator := range fields_filters {
+				field_operators = append(field_operators, field_operator)
+			}
+			sort.Strings(field_operators)
+
+			for _, field_operator := range field_operators {
 				operator := ""
 
 				// map operator code to SQL operator string
@@ -496,33 +756,59 @@ func buildWhereString(andFilter Filter) string {
 					operator = "IN"
 				case "nin":
 					operator = "NOT IN"
+				case "like":
+					operator = " LIKE "
+				case "exists":
+					operator = "EXISTS"
+				case "nexists":
+					operator = "NOT EXISTS"
 				default:
 					log.Panic("Invalid filter operator provided!")
 				}
 
-				// build COL OPERATOR VALUE string
 				arg := fields_filters[field_operator]
-				condition_str := fmt.Sprintf("%v%v%v", camelToSnake(field_name), operator, arg)
 
-				// check type is string
-				if reflect.TypeOf(arg) == reflect.TypeOf("") {
-					condition_str = fmt.Sprintf("%v%v'%v'", camelToSnake(field_name), operator, arg)
+				// EXISTS (SELECT ...) does not compare the field itself
+				if operator == "EXISTS" || operator == "NOT EXISTS" {
+					sub, ok := arg.(SubQuery)
+					if !ok {
+						log.Panicf("Filter %v on %v requires a SubQuery!", field_operator, field_name)
+					}
+					filters = append(filters, fmt.Sprintf("%v (%v)", operator, sub.query))
+					whereArgs = append(whereArgs, sub.args...)
+					continue
+				}
+
+				column, column_args := db.filterColumn(fields, field_name)
+				whereArgs = append(whereArgs, column_args...)
+
+				// build COL OPERATOR ? string
+				value, value_args := db.filterValue(fields, arg)
+				condition_str := fmt.Sprintf("%v%v%v", column, operator, value)
+
+				// nothing is equal to NULL in SQL, so compare with IS
+				if arg == nil && (operator == "=" || operator == "!=") {
+					condition_str = column + " IS NULL"
+					if operator == "!=" {
+						condition_str = column + " IS NOT NULL"
+					}
+					value_args = nil
 				}
 
 				if operator == "IN" || operator == "NOT IN" {
-					values := make([]string, 0)
-					for _, value := range fields_filters[field_operator].([]interface{}) {
-						new_value := value
-						// check type is string
-						if reflect.TypeOf(value) == reflect.TypeOf("") {
-							new_value = fmt.Sprintf("'%v'", new_value)
+					if values, ok := arg.([]interface{}); ok {
+						placeholders := make([]string, len(values))
+						for i := range values {
+							placeholders[i] = "?"
 						}
-						values = append(values, fmt.Sprintf("%v", new_value))
+						value, value_args = "("+strings.Join(placeholders, ",")+")", values
+					} else if _, ok := arg.(SubQuery); !ok {
+						log.Panicf("Filter %v on %v requires a list of values or a SubQuery!", field_operator, field_name)
 					}
-					list_str := fmt.Sprintf("(%v)", strings.Join(values, ","))
-					// COL IN (a, b, ...)
-					condition_str = fmt.Sprintf("%v %v %v", camelToSnake(field_name), operator, list_str)
+					// COL IN (?, ?, ...) or COL IN (SELECT ...)
+					condition_str = fmt.Sprintf("%v %v %v", column, operator, value)
 				}
+				whereArgs = append(whereArgs, value_args...)
 
 				filters = append(filters, condition_str)
 			}
@@ -531,37 +817,159 @@ func buildWhereString(andFilter Filter) string {
 		// construct SQL WHERE string with conditions AND'd together
 		whereString = " WHERE " + strings.Join(filters, " AND ")
 	}
-	return whereString
+	return whereString, whereArgs
+}
+
+// Maps a Filter value to the SQL expression it is compared with, along
+// with any values bound inside that expression. Sub-queries and column
+// references are rendered in place; any other value is bound to a "?".
+func (db *DB) filterValue(fields []modelField, value interface{}) (string, []interface{}) {
+	switch value := value.(type) {
+	case SubQuery:
+		return "(" + value.query + ")", value.args
+	case ColumnRef:
+		return db.fieldColumn(fields, string(value)), nil
+	default:
+		return "?", []interface{}{value}
+	}
+}
+
+// Maps a Filter field name to the SQL expression it compares, along with
+// any values bound inside that expression. "Field->path" refers to a path
+// inside a JSON column; any other name refers to the column itself.
+func (db *DB) filterColumn(fields []modelField, field_name string) (string, []interface{}) {
+	parts := strings.SplitN(field_name, "->", 2)
+	column := db.fieldColumn(fields, strings.TrimSpace(parts[0]))
+	if len(parts) == 1 {
+		return column, nil
+	}
+
+	path := strings.TrimSpace(parts[1])
+	if !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+	return fmt.Sprintf("json_extract(%v, ?)", column), []interface{}{"$" + path}
 }
 
 // Given a model, check if its corresponding table exists in db
 func (db *DB) checkTableExists(model interface{}) string {
-	tablename := TableName(model)
-	query := fmt.Sprintf("SELECT * FROM %v", tablename)
-	rows, err := db.inner.Query(query)
+	tablename := db.tableName(model)
+	if len(db.tableColumns(tablename)) == 0 {
+		log.Panic(fmt.Sprintf("Table %v not found!", tablename))
+	}
+	return tablename
+}
+
+// Checks if a model field is annotated with `dorm:"primary_key"`
+func isPrimaryKey(field modelField) bool {
+	_, ok := field.settings["primary_key"]
+	return ok
+}
+
+// Checks if a struct field carries the `dorm:"json"` tag
+func isJSONField(field reflect.StructField) bool {
+	_, ok := tagSettings(field)["json"]
+	return ok
+}
 
+// Marshals the value of a `dorm:"json"` field into the text stored in its column
+func marshalJSONField(value interface{}) string {
+	encoded, err := json.Marshal(value)
 	if err != nil {
-		log.Panic(fmt.Sprintf("Table %v not found!", tablename))
+		log.Panic(err)
 	}
-	for rows.Next() {
-		// must do this to prevent table not found error
+	return string(encoded)
+}
+
+// Unmarshals the text stored in a `dorm:"json"` column into the field dst
+// NULL or empty columns leave dst at its zero value
+func unmarshalJSONField(encoded []byte, dst reflect.Value) error {
+	if len(encoded) == 0 {
+		return nil
+	}
+	return json.Unmarshal(encoded, dst.Addr().Interface())
+}
+
+// Returns the names of the columns of a table, in table order
+// Returns an empty slice if the table does not exist
+func (db *DB) tableColumns(tablename string) []string {
+	cached, ok, generation := db.tables.get(tablename)
+	if ok {
+		return append([]string{}, cached...)
 	}
 
+	rows, err := db.inner.Query("SELECT name FROM pragma_table_info(?)", tablename)
+	if err != nil {
+		log.Panic(err)
+	}
 	defer rows.Close()
 
-	return tablename
+	columns := []string{}
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			log.Panic(err)
+		}
+		columns = append(columns, column)
+	}
+	if len(columns) > 0 {
+		db.tables.put(tablename, append([]string{}, columns...), generation)
+	}
+	return columns
 }
 
-// Converts camel case to underscore (snake) case
-// Source: https://stackoverflow.com/a/56616250
+/*
+	Converts camel case to underscore (snake) case, e.g. FullName ==> full_name.
+
+	The name is split into words, each of which is lowercased and joined
+	with underscores. A new word starts at an uppercase letter that follows:
+	- a lowercase letter or a digit: UserID ==> user_id, Sha256Sum ==> sha256_sum
+	- an uppercase letter, when it is itself followed by a lowercase letter,
+	  ending an acronym: HTTPStatus ==> http_status, OAuth2Token ==> o_auth2_token
+	Digits never start a word, so they stay attached to the word before them:
+	Address2 ==> address2, Base64URL ==> base64_url.
+	A lowercase "s" directly closing an acronym is treated as a plural rather
+	than the start of a word: UserIDs ==> user_ids, URLsSeen ==> urls_seen.
+	Existing underscores are kept and never doubled: User_ID ==> user_id.
+
+	A single uppercase letter before a capitalized word is a word of its
+	own, so OAuth ==> o_auth and OAuth2Token ==> o_auth2_token, as for
+	XAxis ==> x_axis. Telling such brand names apart from one-letter words
+	would take a list of them, and this is the conversion names have always
+	had, so existing columns keep their names. Tag a field with
+	`dorm:"column:oauth2_token"` to choose another.
+
+	See TestCamelToSnake for the full specification.
+*/
 func camelToSnake(camel string) string {
-	matchFirstCap := regexp.MustCompile("(.)([A-Z][a-z]+)")
-	matchAllCap := regexp.MustCompile("([a-z0-9])([A-Z])")
-
-	snake := matchFirstCap.ReplaceAllString(camel, "${1}_${2}")
-	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
+	runes := []rune(camel)
+	var snake strings.Builder
+	for i, r := range runes {
+		if i > 0 && runes[i-1] != '_' && startsWord(runes, i) {
+			snake.WriteRune('_')
+		}
+		snake.WriteRune(unicode.ToLower(r))
+	}
+	return snake.String()
+}
 
-	return strings.ToLower(snake)
+// Checks if the rune at index i of a camel case name starts a new word
+// (see camelToSnake)
+func startsWord(runes []rune, i int) bool {
+	if !unicode.IsUpper(runes[i]) {
+		return false
+	}
+	prev := runes[i-1]
+	if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+		return true
+	}
+	if !unicode.IsUpper(prev) || i+1 == len(runes) || !unicode.IsLower(runes[i+1]) {
+		return false
+	}
+	// an uppercase letter inside an acronym starts a word only if it is
+	// followed by a lowercase letter other than a plural "s"
+	plural := runes[i+1] == 's' && (i+2 == len(runes) || !unicode.IsLower(runes[i+2]))
+	return !plural
 }
 
 // Checks if string a is in slice list
@@ -575,11 +983,22 @@ func stringInSlice(a string, list []interface{}) bool {
 	return false
 }
 
+// Checks if string a is in slice list
+func containsString(list []string, a string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+	return false
+}
+
 /*
 	Analyzes a struct, v, and returns a list of strings,
 	one for each of the public fields of v.
 	The i'th string returned should be equal to the name of the i'th
-	public field of v, converted to underscore_case.
+	public field of v, converted to underscore_case. Fields of embedded
+	structs are flattened in place (see modelFields).
 
 	Example usage:
 	type MyStruct struct {
@@ -589,15 +1008,9 @@ func stringInSlice(a string, list []interface{}) bool {
 	columnNames(&MyStruct{}) ==> []string{"id", "user_name"}
 */
 func columnNames(v interface{}) []interface{} {
-	val := reflect.ValueOf(v).Elem()
 	cols := []interface{}{}
-	for i := 0; i < val.NumField(); i++ {
-		colname := val.Type().Field(i).Name
-		if unicode.IsLower([]rune(colname)[0]) {
-			continue
-		}
-		colname_fixed := camelToSnake(colname)
-		cols = append(cols, colname_fixed)
+	for _, field := range modelFields(reflect.TypeOf(v).Elem(), SnakeCaseNaming{}) {
+		cols = append(cols, field.column)
 	}
 	return cols
 }
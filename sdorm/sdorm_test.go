@@ -2,6 +2,7 @@ package sdorm
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"testing"
 
@@ -80,6 +81,18 @@ func helperTestPanic(t *testing.T, theFunc func()) {
 	theFunc()
 }
 
+// Checks that theFunc panics with err, or an error wrapping it
+func helperTestPanicError(t *testing.T, err error, theFunc func()) {
+	defer func() {
+		r := recover()
+		if recovered, ok := r.(error); !ok || !errors.Is(recovered, err) {
+			t.Errorf("Expected panic with %v but instead got %v", err, r)
+		}
+	}()
+
+	theFunc()
+}
+
 func TestProjection(t *testing.T) {
 	fmt.Println(">>> PROJECTION TESTS <<<")
 	conn := connectSQL()
@@ -788,15 +801,40 @@ func TestDelete(t *testing.T) {
 
 	/* ------------------------------------------------------------ */
 
+	db.Create(&user_nick)
+
+	fmt.Println("Test: Delete Through a Pointer to a Slice")
+	filter = make(Filter)
+	addFilter(filter, "FullName", "eq", "Nick")
+	args = DeleteOrUpdateArgs{
+		andFilter: filter,
+	}
+	model := []User{}
+	rows_deleted = db.Delete(&model, args)
+	helperTestIntEquality(t, rows_deleted, 1)
+
+	results = []User{}
+	db.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_will,
+	})
+
+	/* ------------------------------------------------------------ */
+
 	db.Create(&user_shannon)
 	db.Create(&user_nick)
 
-	fmt.Println("Test: Delete All Rows")
+	fmt.Println("Test: Delete All Rows Requires AllowGlobal")
 	filter = make(Filter)
 	args = DeleteOrUpdateArgs{
 		andFilter: filter,
 	}
-	rows_deleted = db.Delete(&User{}, args)
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Delete(&User{}, args)
+	})
+
+	fmt.Println("Test: Delete All Rows")
+	rows_deleted = db.AllowGlobal().Delete(&User{}, args)
 	helperTestIntEquality(t, rows_deleted, 3)
 
 	results = []User{}
@@ -933,6 +971,11 @@ func TestUpdateAll1(t *testing.T) {
 	updates := make(Updates)
 	addUpdate(updates, "ClassYear", "Sophomore")
 
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Update(&User{}, args, updates)
+	})
+
+	db.SetAllowGlobal(true)
 	rows_updated := db.Update(&User{}, args, updates)
 	helperTestIntEquality(t, rows_updated, 3)
 
@@ -1071,7 +1114,7 @@ func TestUpdateBadType(t *testing.T) {
 	addUpdate(updates, "ClassYear", 0)
 
 	helperTestPanic(t, func() {
-		db.Update(&User{}, args, updates)
+		db.AllowGlobal().Update(&User{}, args, updates)
 	})
 }
 
@@ -0,0 +1,161 @@
+package sdorm
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Most rows whose snapshots are kept; past it, all are dropped and Save
+// writes every column of rows until they are read again
+const maxSnapshots = 10000
+
+/*
+	Column values of rows as last read or written through a DB that
+	tracks changes (see SetTrackChanges), keyed by table and primary key,
+	so that Save writes only the columns of a model that changed since it
+	was found (dirty tracking). A row without a snapshot is written in
+	full, so snapshots may be dropped at any time, and those of a table
+	are dropped whenever rows of it are written other than by Save:
+	by Update, Delete and Restore, and by Exec and Raw for every table.
+
+	The store is shared by every copy of a DB (see Scopes and Transaction).
+*/
+type snapshotStore struct {
+	mu     sync.Mutex
+	tables map[string]map[string]map[string]interface{}
+	size   int
+}
+
+// Returns the key of the row with primary key value key within its table
+func snapshotKey(key interface{}) string {
+	return fmt.Sprint(key)
+}
+
+// Returns the snapshot of the row of table with the given key, or false
+// if there is none
+func (store *snapshotStore) get(table string, key string) (map[string]interface{}, bool) {
+	if store == nil {
+		return nil, false
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	row, ok := store.tables[table][key]
+	return row, ok
+}
+
+// Records the given column values of the row of table with the given key.
+// Unless all is set, they are merged into the row's snapshot, if it has one
+func (store *snapshotStore) put(table string, key string, values map[string]interface{}, all bool) {
+	if store == nil {
+		return
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	old, ok := store.tables[table][key]
+	if !all {
+		if !ok {
+			return
+		}
+		merged := make(map[string]interface{}, len(old))
+		for column, value := range old {
+			merged[column] = value
+		}
+		for column, value := range values {
+			merged[column] = value
+		}
+		values = merged
+	}
+	if !ok && store.size >= maxSnapshots {
+		store.tables = nil
+		store.size = 0
+	}
+	if store.tables == nil {
+		store.tables = make(map[string]map[string]map[string]interface{})
+	}
+	if store.tables[table] == nil {
+		store.tables[table] = make(map[string]map[string]interface{})
+	}
+	if _, ok := store.tables[table][key]; !ok {
+		store.size++
+	}
+	store.tables[table][key] = values
+}
+
+// Drops the snapshots of the rows of table, e.g. when they are written
+// by a statement that may change any of them
+func (store *snapshotStore) clearTable(table string) {
+	if store == nil {
+		return
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.size -= len(store.tables[table])
+	delete(store.tables, table)
+}
+
+// Drops every snapshot, e.g. when writes they recorded are rolled back
+func (store *snapshotStore) clear() {
+	if store == nil {
+		return
+	}
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.tables = nil
+	store.size = 0
+}
+
+/*
+	SetTrackChanges enables or disables dirty tracking, which is off by
+	default. While it is on, db remembers the column values of the models
+	it finds, creates and saves, and Save writes only the columns of a
+	model that changed since (see Save). Tracking costs a copy of the
+	column values of every model found, so it is best enabled only on
+	DBs whose models are saved back.
+*/
+func (db *DB) SetTrackChanges(track bool) {
+	db.trackChanges = track
+	if !track {
+		db.snapshots.clear()
+	}
+}
+
+// Records the stored values of the given fields of the model v_model in
+// table (all fields if names is nil), if db tracks changes. Models
+// without a key are skipped
+func (db *DB) recordSnapshot(table string, v_model reflect.Value, fields []modelField, names []string) {
+	if !db.trackChanges {
+		return
+	}
+	pk, ok := primaryKeyField(fields)
+	if !ok {
+		return
+	}
+	values := make(map[string]interface{})
+	for _, field := range fields {
+		if names == nil || containsString(names, field.name) {
+			values[field.name] = columnValue(v_model, field, fields)
+		}
+	}
+	db.snapshots.put(table, snapshotKey(v_model.FieldByIndex(pk.index).Interface()), values, names == nil)
+}
+
+// Returns the names of the fields of the model v_model in table whose
+// values differ from its row's snapshot, or false if it has no snapshot
+func (db *DB) changedFields(table string, v_model reflect.Value, fields []modelField, pk modelField) ([]string, bool) {
+	if !db.trackChanges {
+		return nil, false
+	}
+	snapshot, ok := db.snapshots.get(table, snapshotKey(v_model.FieldByIndex(pk.index).Interface()))
+	if !ok {
+		return nil, false
+	}
+	changed := []string{}
+	for _, field := range fields {
+		if !reflect.DeepEqual(snapshot[field.name], columnValue(v_model, field, fields)) {
+			changed = append(changed, field.name)
+		}
+	}
+	return changed, true
+}
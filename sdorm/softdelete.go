@@ -0,0 +1,157 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strings"
+	"time"
+)
+
+/*
+	Unscoped returns a copy of db that ignores soft deletion: its queries
+	include soft-deleted rows and its Delete removes rows for good.
+	db itself is unchanged.
+
+	Models with a field tagged `dorm:"soft_delete"`, or else a field named
+	DeletedAt, of type time.Time or *time.Time, are soft-deleted: Delete
+	sets the field's column to the current time instead of removing rows,
+	and Find, Update and Count skip rows where it is not NULL, in the
+	model's table and in tables it joins (see Join). Rows can be
+	brought back with Restore and removed for good with HardDelete.
+
+	A query that filters on the soft delete field itself, e.g. to find
+	rows deleted after some time, sees soft-deleted rows too.
+
+	Example usage to find all users, deleted or not:
+	db.Unscoped().Find(&results, FindArgs{})
+*/
+func (db *DB) Unscoped() *DB {
+	unscoped := *db
+	unscoped.unscoped = true
+	return &unscoped
+}
+
+/*
+	Restore brings back soft-deleted rows of model's table matching args,
+	setting their soft delete column back to NULL. Returns the number of
+	rows restored.
+
+	Restore panics if the model has no soft delete field, if the
+	generated SQL query string is invalid, or if the table does not exist.
+	Like Update, it panics with ErrMissingWhereClause if args and db's
+	scopes have no filter, unless db allows it (see AllowGlobal).
+
+	Example usage:
+	filter := make(Filter)
+	addFilter(filter, "FullName", "eq", "Nick")
+	rows_restored := db.Restore(&User{}, DeleteOrUpdateArgs{andFilter: filter})
+*/
+func (db *DB) Restore(model interface{}, args DeleteOrUpdateArgs) int {
+	tablename := db.checkTableExists(model)
+	model_fields := db.modelFields(modelType(model))
+	deleted_at, ok := softDeleteField(model_fields)
+	if !ok {
+		log.Panicf("Model %v has no soft delete field!", modelType(model))
+	}
+
+	filter := db.scopedFilter(args.andFilter)
+	if err := db.checkQueryFields(tablename, model_fields, filter, nil); err != nil {
+		panic(err)
+	}
+	if err := db.checkGlobal(filter); err != nil {
+		panic(err)
+	}
+	if _, ok := filter[deleted_at.name]; !ok {
+		filter = copyFilter(filter)
+		addFilter(filter, deleted_at.name, "neq", nil)
+	}
+	where_string, where_args := db.buildWhereString(filter, model_fields)
+	query := fmt.Sprintf("UPDATE %v SET %v=NULL", tablename, deleted_at.column) + where_string
+	return db.execRowsAffected(tablename, query, where_args)
+}
+
+/*
+	HardDelete removes rows of model's table matching args for good, as
+	Delete does for models without a soft delete field. Soft-deleted rows
+	are removed too. Returns the number of rows deleted.
+
+	HardDelete panics if the generated SQL query string is invalid, or if
+	the table does not exist.
+*/
+func (db *DB) HardDelete(model interface{}, args DeleteOrUpdateArgs) int {
+	return db.Unscoped().Delete(model, args)
+}
+
+// Soft-deletes rows of model's table matching args (see Delete)
+func (db *DB) softDelete(model interface{}, tablename string, deleted_at modelField, args DeleteOrUpdateArgs) int {
+	model_fields := db.modelFields(modelType(model))
+	where_string, where_args := db.buildWhereString(db.softDeleteFilter(model, db.scopedFilter(args.andFilter), false), model_fields)
+	query := fmt.Sprintf("UPDATE %v SET %v=?", tablename, deleted_at.column) + where_string
+	return db.execRowsAffected(tablename, query, append([]interface{}{db.currentTime()}, where_args...))
+}
+
+// Returns filter on model's table with a condition skipping soft-deleted
+// rows added, unless db is unscoped, the model has no soft delete field or
+// filter already has conditions on it. The field is qualified by its table
+// when qualify is true, for queries joining other tables.
+func (db *DB) softDeleteFilter(model interface{}, filter Filter, qualify bool) Filter {
+	if db.unscoped {
+		return filter
+	}
+	deleted_at, ok := softDeleteField(db.modelFields(modelType(model)))
+	if !ok {
+		return filter
+	}
+
+	name := deleted_at.name
+	if qualify {
+		name = db.tableName(model) + "." + name
+	}
+	for field_name := range filter {
+		unqualified := field_name[strings.LastIndex(field_name, ".")+1:]
+		if unqualified == deleted_at.name || unqualified == deleted_at.column {
+			return filter
+		}
+	}
+
+	filter = copyFilter(filter)
+	addFilter(filter, name, "eq", nil)
+	return filter
+}
+
+// Returns the field marking rows of a model soft-deleted: the field tagged
+// `dorm:"soft_delete"`, or else the field named DeletedAt, or false if the
+// model has neither
+func softDeleteField(fields []modelField) (modelField, bool) {
+	for _, field := range fields {
+		if _, ok := field.settings["soft_delete"]; ok {
+			return field, true
+		}
+	}
+	if field, ok := findModelField(fields, "DeletedAt"); ok {
+		t := field.field.Type
+		if t == reflect.TypeOf(time.Time{}) || t == reflect.TypeOf(&time.Time{}) {
+			return field, true
+		}
+	}
+	return modelField{}, false
+}
+
+// Returns a copy of filter that can be changed without changing filter
+func copyFilter(filter Filter) Filter {
+	copied := make(Filter, len(filter))
+	for field, field_filter := range filter {
+		copied[field] = make(FilterArg, len(field_filter))
+		for operator, value := range field_filter {
+			copied[field][operator] = value
+		}
+	}
+	return copied
+}
+
+// Checks if field is the soft delete field among a model's fields
+func isSoftDeleteField(fields []modelField, field modelField) bool {
+	deleted_at, ok := softDeleteField(fields)
+	return ok && deleted_at.name == field.name
+}
@@ -0,0 +1,218 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Soft-deleted through its DeletedAt field
+type Note struct {
+	ID        int64 `dorm:"primary_key"`
+	Body      string
+	DeletedAt *time.Time
+}
+
+// Soft-deleted through its tagged RemovedAt field
+type Task struct {
+	ID        int64 `dorm:"primary_key"`
+	Title     string
+	RemovedAt time.Time `dorm:"soft_delete"`
+}
+
+// Shelf holding Books, which are soft-deleted
+type Shelf struct {
+	ID   int64 `dorm:"primary_key"`
+	Name string
+}
+
+type Book struct {
+	ID        int64 `dorm:"primary_key"`
+	ShelfID   int64
+	Title     string
+	DeletedAt *time.Time
+}
+
+// Returns the bodies of notes, in order
+func noteBodies(notes []Note) []string {
+	bodies := []string{}
+	for _, note := range notes {
+		bodies = append(bodies, note.Body)
+	}
+	return bodies
+}
+
+func helperTestNotes(t *testing.T, db *DB, args FindArgs, expected []string) {
+	notes := []Note{}
+	db.Find(&notes, args)
+	if fmt.Sprint(noteBodies(notes)) != fmt.Sprint(expected) {
+		t.Errorf("Expected notes %v but instead found %v", expected, noteBodies(notes))
+	}
+}
+
+func TestSoftDelete(t *testing.T) {
+	fmt.Println(">>> SOFT DELETE TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+
+	db.AutoMigrate(&Note{})
+	for _, body := range []string{"alpha", "beta", "gamma"} {
+		db.Create(&Note{Body: body})
+	}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Delete Sets DeletedAt")
+	filter := make(Filter)
+	addFilter(filter, "Body", "eq", "beta")
+	rows_deleted := db.Delete(&Note{}, DeleteOrUpdateArgs{andFilter: filter})
+	helperTestIntEquality(t, rows_deleted, 1)
+	helperTestNotes(t, &db, FindArgs{}, []string{"alpha", "gamma"})
+
+	deleted := []Note{}
+	db.Unscoped().Find(&deleted, FindArgs{andFilter: filter})
+	if len(deleted) != 1 || deleted[0].DeletedAt == nil || time.Since(*deleted[0].DeletedAt) > time.Minute {
+		t.Errorf("Expected beta to be marked deleted but instead found %+v", deleted)
+	}
+
+	fmt.Println("Test: Deleting Again Affects No Rows")
+	rows_deleted = db.Delete(&Note{}, DeleteOrUpdateArgs{andFilter: filter})
+	helperTestIntEquality(t, rows_deleted, 0)
+
+	fmt.Println("Test: Count and Update Skip Deleted Rows")
+	count, err := db.Count(&Note{}, FindArgs{})
+	if err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	helperTestIntEquality(t, count, 2)
+	count, _ = db.Unscoped().Count(&Note{}, FindArgs{})
+	helperTestIntEquality(t, count, 3)
+	updates := make(Updates)
+	addUpdate(updates, "Body", "updated")
+	rows_updated := db.AllowGlobal().Update(&Note{}, DeleteOrUpdateArgs{}, updates)
+	helperTestIntEquality(t, rows_updated, 2)
+	helperTestNotes(t, db.Unscoped(), FindArgs{}, []string{"updated", "beta", "updated"})
+
+	fmt.Println("Test: Filter on DeletedAt Finds Deleted Rows")
+	helperTestNotes(t, &db, FindArgs{andFilter: Filter{"DeletedAt": FilterArg{"neq": nil}}}, []string{"beta"})
+
+	fmt.Println("Test: Restore")
+	helperTestPanicError(t, ErrMissingWhereClause, func() {
+		db.Restore(&Note{}, DeleteOrUpdateArgs{})
+	})
+	rows_restored := db.AllowGlobal().Restore(&Note{}, DeleteOrUpdateArgs{})
+	helperTestIntEquality(t, rows_restored, 1)
+	helperTestNotes(t, &db, FindArgs{}, []string{"updated", "beta", "updated"})
+
+	fmt.Println("Test: Hard Delete")
+	db.Delete(&Note{}, DeleteOrUpdateArgs{andFilter: filter})
+	rows_deleted = db.HardDelete(&Note{}, DeleteOrUpdateArgs{andFilter: filter})
+	helperTestIntEquality(t, rows_deleted, 1)
+	rows_deleted = db.Unscoped().AllowGlobal().Delete(&Note{}, DeleteOrUpdateArgs{})
+	helperTestIntEquality(t, rows_deleted, 2)
+	count, _ = db.Unscoped().Count(&Note{}, FindArgs{})
+	helperTestIntEquality(t, count, 0)
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Restore Without Soft Delete Field")
+		db.Restore(&Post{}, DeleteOrUpdateArgs{})
+	})
+}
+
+func TestSoftDeleteTag(t *testing.T) {
+	fmt.Println(">>> SOFT DELETE TAG TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+
+	db.AutoMigrate(&Task{})
+	db.Create(&Task{Title: "write"})
+	db.Create(&Task{Title: "review"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Delete Sets Tagged Field")
+	rows_deleted := db.Delete(&Task{}, DeleteOrUpdateArgs{andFilter: Filter{"Title": FilterArg{"eq": "write"}}})
+	helperTestIntEquality(t, rows_deleted, 1)
+	tasks := []Task{}
+	db.Find(&tasks, FindArgs{})
+	if len(tasks) != 1 || tasks[0].Title != "review" || !tasks[0].RemovedAt.IsZero() {
+		t.Errorf("Expected [review] but instead found %+v", tasks)
+	}
+
+	fmt.Println("Test: First Skips Deleted Rows")
+	task := Task{}
+	db.First(&task, FindArgs{})
+	if task.Title != "review" {
+		t.Errorf("Expected review but instead found %+v", task)
+	}
+	db.Unscoped().First(&task, FindArgs{})
+	if task.Title != "write" || task.RemovedAt.IsZero() {
+		t.Errorf("Expected deleted task write but instead found %+v", task)
+	}
+}
+
+func TestFilterNull(t *testing.T) {
+	fmt.Println(">>> FILTER NULL TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+	if _, err := db.Exec("UPDATE user SET class_year = NULL WHERE age > 20"); err != nil {
+		panic(err)
+	}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Eq Nil")
+	count, _ := db.Count(&User{}, FindArgs{andFilter: Filter{"ClassYear": FilterArg{"eq": nil}}})
+	helperTestIntEquality(t, count, 2)
+
+	fmt.Println("Test: Neq Nil")
+	count, _ = db.Count(&User{}, FindArgs{andFilter: Filter{"ClassYear": FilterArg{"neq": nil}}})
+	helperTestIntEquality(t, count, 3)
+}
+
+func TestSoftDeleteJoins(t *testing.T) {
+	fmt.Println(">>> SOFT DELETE JOIN TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+
+	db.AutoMigrate(&Shelf{}, &Book{})
+	shelf := Shelf{Name: "fiction"}
+	db.Create(&shelf)
+	book := Book{ShelfID: shelf.ID, Title: "Dune"}
+	db.Create(&book)
+	db.Delete(&Book{}, DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": book.ID}}})
+
+	type ShelfBook struct {
+		Name  string
+		Title string
+	}
+	findShelfBooks := func(db *DB, kind string) []ShelfBook {
+		joins := new(Joins)
+		addJoin(joins, kind, &Book{}, "shelf.ID", "book.ShelfID")
+		results := []ShelfBook{}
+		db.Find(&results, FindArgs{from: &Shelf{}, joins: *joins})
+		return results
+	}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Inner Join Skips Soft-Deleted Rows")
+	if results := findShelfBooks(&db, "INNER"); len(results) != 0 {
+		t.Errorf("Expected no rows but instead found %v", results)
+	}
+
+	fmt.Println("Test: Left Join Keeps Rows Before")
+	expected := []ShelfBook{{Name: "fiction"}}
+	if results := findShelfBooks(&db, "LEFT"); fmt.Sprint(results) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but instead found %v", expected, results)
+	}
+
+	fmt.Println("Test: Unscoped Join")
+	expected = []ShelfBook{{Name: "fiction", Title: "Dune"}}
+	if results := findShelfBooks(db.Unscoped(), "INNER"); fmt.Sprint(results) != fmt.Sprint(expected) {
+		t.Errorf("Expected %v but instead found %v", expected, results)
+	}
+}
@@ -0,0 +1,47 @@
+package sdorm
+
+/*
+	A query built from FindArgs that can be used as a value in a Filter,
+	so one statement can filter on the results of another. The sub-query
+	keeps the values bound to its placeholders, and they are bound in
+	order when the enclosing statement is built, so the whole statement
+	stays parameterized.
+
+	A Filter in the sub-query can refer to a column of the enclosing query
+	with a ColumnRef, making it a correlated sub-query.
+
+	Example usage to find users as old as any senior:
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	seniors := db.SubQuery(&User{}, FindArgs{projection: []interface{}{"Age"}, andFilter: filter})
+	filter = make(Filter)
+	addFilter(filter, "Age", "in", seniors)
+	db.Find(&results, FindArgs{andFilter: filter})
+
+	Example usage to find authors with at least one post:
+	filter := make(Filter)
+	addFilter(filter, "WriterID", "eq", ColumnRef("author.ID"))
+	posts := db.SubQuery(&Post{}, FindArgs{andFilter: filter})
+	filter = make(Filter)
+	addFilter(filter, "has_posts", "exists", posts)
+	db.Find(&authors, FindArgs{andFilter: filter})
+*/
+type SubQuery struct {
+	query string
+	args  []interface{}
+}
+
+// A reference to a column, as a Filter value (see SubQuery)
+type ColumnRef string
+
+// SubQuery builds the query Find would run to find rows of model's table
+// with args, without running it. Preloads in args are ignored.
+func (db *DB) SubQuery(model interface{}, args FindArgs) SubQuery {
+	args = db.applyScopes(args)
+	base := model
+	if args.from != nil {
+		base = args.from
+	}
+	query, query_args := db.buildFindQuery(modelType(model), base, args)
+	return SubQuery{query: query, args: query_args}
+}
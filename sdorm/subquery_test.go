@@ -0,0 +1,133 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubQuery(t *testing.T) {
+	fmt.Println(">>> SUBQUERY TESTS <<<")
+	db := populateVideoDemoDb()
+	defer db.Close()
+
+	user_nick := User{FullName: "Nick", ClassYear: "Freshman", Age: 10, IsEnrolled: true}
+	user_shannon := User{FullName: "Shannon", ClassYear: "Freshman", Age: 20, IsEnrolled: false}
+	user_will := User{FullName: "Will", ClassYear: "Senior", Age: 20, IsEnrolled: true}
+	user_katie := User{FullName: "Katie", ClassYear: "Sophomore", Age: 30, IsEnrolled: false}
+	user_albert := User{FullName: "Albert", ClassYear: "Senior", Age: 40, IsEnrolled: true}
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Age in Ages of Seniors")
+	filter := make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	seniors := db.SubQuery(&User{}, FindArgs{projection: []interface{}{"Age"}, andFilter: filter})
+	results := []User{}
+	filter = make(Filter)
+	addFilter(filter, "Age", "in", seniors)
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestEquality(t, results, []User{
+		user_shannon,
+		user_will,
+		user_albert,
+	})
+
+	fmt.Println("Test: Age not in Ages of Seniors, Parameters in Order")
+	filter = make(Filter)
+	addFilter(filter, "ClassYear", "eq", "Senior")
+	addFilter(filter, "IsEnrolled", "eq", true)
+	seniors = db.SubQuery(&User{}, FindArgs{projection: []interface{}{"Age"}, andFilter: filter})
+	results = []User{}
+	filter = make(Filter)
+	addFilter(filter, "Age", "nin", seniors)
+	addFilter(filter, "FullName", "neq", "Nick")
+	addFilter(filter, "ClassYear", "neq", "Senior")
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestEquality(t, results, []User{
+		user_katie,
+	})
+
+	fmt.Println("Test: Age > Scalar Sub-Query")
+	orderBy := new(OrderBy)
+	addOrder(orderBy, "Age", "ASC")
+	youngest_senior := db.SubQuery(&User{}, FindArgs{
+		projection: []interface{}{"Age"},
+		andFilter:  Filter{"ClassYear": FilterArg{"eq": "Senior"}},
+		orderBy:    *orderBy,
+		limit:      1,
+	})
+	results = []User{}
+	filter = make(Filter)
+	addFilter(filter, "Age", "gt", youngest_senior)
+	db.Find(&results, FindArgs{andFilter: filter})
+	helperTestEquality(t, results, []User{
+		user_katie,
+		user_albert,
+	})
+
+	fmt.Println("Test: Delete With Sub-Query")
+	filter = make(Filter)
+	addFilter(filter, "Age", "in", db.SubQuery(&User{}, FindArgs{projection: []interface{}{"Age"}, andFilter: Filter{"Age": FilterArg{"geq": 30}}}))
+	rows_deleted := db.Delete(&User{}, DeleteOrUpdateArgs{andFilter: filter})
+	helperTestIntEquality(t, rows_deleted, 2)
+	results = []User{}
+	db.Find(&results, FindArgs{})
+	helperTestEquality(t, results, []User{
+		user_nick,
+		user_shannon,
+		user_will,
+	})
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Exists Without Sub-Query")
+		filter = make(Filter)
+		addFilter(filter, "Age", "exists", 10)
+		db.Find(&results, FindArgs{andFilter: filter})
+	})
+}
+
+func TestExists(t *testing.T) {
+	fmt.Println(">>> EXISTS TESTS <<<")
+	conn := connectSQL()
+	createAuthorTables(conn)
+
+	db := NewDB(conn)
+	defer db.Close()
+
+	author_nick := Author{FullName: "Nick"}
+	author_shannon := Author{FullName: "Shannon"}
+	author_will := Author{FullName: "Will"}
+	db.Create(&author_nick)
+	db.Create(&author_shannon)
+	db.Create(&author_will)
+	db.Create(&Post{WriterID: int(author_nick.ID), Title: "Intro to ORMs"})
+	db.Create(&Post{WriterID: int(author_shannon.ID), Title: "Reflection in Go"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Authors With Posts Mentioning Go")
+	filter := make(Filter)
+	addFilter(filter, "WriterID", "eq", ColumnRef("author.ID"))
+	addFilter(filter, "Title", "like", "%Go%")
+	posts := db.SubQuery(&Post{}, FindArgs{andFilter: filter})
+	authors := []Author{}
+	filter = make(Filter)
+	addFilter(filter, "posts", "exists", posts)
+	db.Find(&authors, FindArgs{andFilter: filter})
+	if len(authors) != 1 || authors[0].FullName != "Shannon" {
+		t.Errorf("Expected [Shannon] but instead found %+v", authors)
+	}
+
+	fmt.Println("Test: Authors Without Posts")
+	filter = make(Filter)
+	addFilter(filter, "WriterID", "eq", ColumnRef("author.ID"))
+	posts = db.SubQuery(&Post{}, FindArgs{andFilter: filter})
+	authors = []Author{}
+	filter = make(Filter)
+	addFilter(filter, "posts", "nexists", posts)
+	addFilter(filter, "FullName", "neq", "Nick")
+	db.Find(&authors, FindArgs{andFilter: filter})
+	if len(authors) != 1 || authors[0].FullName != "Will" {
+		t.Errorf("Expected [Will] but instead found %+v", authors)
+	}
+}
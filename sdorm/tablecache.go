@@ -0,0 +1,62 @@
+package sdorm
+
+import "sync"
+
+/*
+	Columns of the tables a DB has found, keyed by table name, so that
+	Create, Update, Delete and the queries that check a table's columns
+	look each table up once rather than before every statement. Only
+	tables that exist are cached, so a table created later is still found.
+
+	The cache is shared by every copy of a DB (see Scopes and Transaction)
+	and dropped whenever the schema may have changed: by AutoMigrate, by
+	Exec and Raw statements other than SELECTs, and when a transaction
+	rolls back. Each drop starts a new generation, and columns looked up
+	in an earlier one are not cached, so a lookup racing a schema change
+	cannot cache the schema from before it.
+*/
+type tableCache struct {
+	mu         sync.Mutex
+	columns    map[string][]string
+	generation int
+}
+
+// Returns the cached columns of table, or false if it has none, along
+// with the current generation to pass to put
+func (cache *tableCache) get(table string) ([]string, bool, int) {
+	if cache == nil {
+		return nil, false, 0
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	columns, ok := cache.columns[table]
+	return columns, ok, cache.generation
+}
+
+// Caches the columns of table, looked up in the given generation, unless
+// the cache was dropped since
+func (cache *tableCache) put(table string, columns []string, generation int) {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if generation != cache.generation {
+		return
+	}
+	if cache.columns == nil {
+		cache.columns = make(map[string][]string)
+	}
+	cache.columns[table] = columns
+}
+
+// Drops every cached table
+func (cache *tableCache) clear() {
+	if cache == nil {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	cache.columns = nil
+	cache.generation++
+}
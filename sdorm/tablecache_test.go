@@ -0,0 +1,85 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// A Note with a column added by a later migration
+type NoteV2 struct {
+	ID        int64 `dorm:"primary_key"`
+	Body      string
+	Pinned    bool
+	DeletedAt *time.Time
+}
+
+func (NoteV2) TableName() string {
+	return "note"
+}
+
+func TestTableCache(t *testing.T) {
+	fmt.Println(">>> TABLE CACHE TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Note{})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Existing Tables Cached")
+	db.Create(&Note{Body: "first"})
+	if columns, ok, _ := db.tables.get("note"); !ok || fmt.Sprint(columns) != "[id body deleted_at]" {
+		t.Errorf("Expected note's columns to be cached but instead found %v", columns)
+	}
+	helperTestPanic(t, func() {
+		db.Create(&User{})
+	})
+	if _, ok, _ := db.tables.get("user"); ok {
+		t.Errorf("Expected a missing table not to be cached")
+	}
+
+	fmt.Println("Test: AutoMigrate Invalidates")
+	db.AutoMigrate(&NoteV2{})
+	rows, err := db.FindMaps("note", FindArgs{projection: []interface{}{"pinned"}})
+	if err != nil || len(rows) != 1 {
+		t.Errorf("Expected the migrated column to be found but instead got %v (error %v)", rows, err)
+	}
+
+	fmt.Println("Test: Exec Invalidates")
+	if _, err := db.Exec("DROP TABLE note"); err != nil {
+		panic(err)
+	}
+	helperTestPanic(t, func() {
+		db.Create(&Note{Body: "second"})
+	})
+
+	fmt.Println("Test: Raw Invalidates")
+	db.AutoMigrate(&Note{})
+	db.Create(&Note{Body: "again"})
+	count := 0
+	if err := db.Raw(&count, "DROP TABLE note"); err != nil {
+		panic(err)
+	}
+	helperTestPanic(t, func() {
+		db.Create(&Note{Body: "dropped"})
+	})
+
+	fmt.Println("Test: Lookups From Before a Drop Not Cached")
+	_, _, generation := db.tables.get("note")
+	db.tables.clear()
+	db.tables.put("note", []string{"id", "body"}, generation)
+	if _, ok, _ := db.tables.get("note"); ok {
+		t.Errorf("Expected columns looked up before the drop not to be cached")
+	}
+
+	fmt.Println("Test: Rollback Invalidates")
+	db.Transaction(func(tx *DB) error {
+		tx.AutoMigrate(&Note{})
+		tx.Create(&Note{Body: "rolled back"})
+		return fmt.Errorf("roll back")
+	})
+	helperTestPanic(t, func() {
+		db.Create(&Note{Body: "third"})
+	})
+}
@@ -0,0 +1,91 @@
+package sdorm
+
+import (
+	"log"
+	"reflect"
+	"time"
+)
+
+/*
+	SetNowFunc sets the clock db reads the current time from, for
+	CreatedAt, UpdatedAt and soft delete timestamps. Passing nil restores
+	the default, time.Now.
+
+	Example usage to freeze time in a test:
+	frozen := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	db.SetNowFunc(func() time.Time { return frozen })
+*/
+func (db *DB) SetNowFunc(now func() time.Time) {
+	db.now = now
+}
+
+// Returns the current time according to db's clock
+func (db *DB) currentTime() time.Time {
+	if db.now == nil {
+		return time.Now()
+	}
+	return db.now()
+}
+
+/*
+	Returns the field of a model stamped with the time its row is created
+	(setting "autocreatetime") or last written (setting "autoupdatetime"):
+	the field tagged `dorm:"<setting>"`, or else the field with the given
+	name if it is a time.Time or *time.Time. Tagged fields may also be
+	integers, which hold Unix seconds.
+*/
+func timestampField(fields []modelField, setting string, name string) (modelField, bool) {
+	for _, field := range fields {
+		if _, ok := field.settings[setting]; ok {
+			return field, true
+		}
+	}
+	if field, ok := findModelField(fields, name); ok && isTimeType(field.field.Type) {
+		return field, true
+	}
+	return modelField{}, false
+}
+
+// Returns the field stamped when a row is created (see timestampField)
+func createdAtField(fields []modelField) (modelField, bool) {
+	return timestampField(fields, "autocreatetime", "CreatedAt")
+}
+
+// Returns the field stamped whenever a row is written (see timestampField)
+func updatedAtField(fields []modelField) (modelField, bool) {
+	return timestampField(fields, "autoupdatetime", "UpdatedAt")
+}
+
+// Checks if t is time.Time or *time.Time
+func isTimeType(t reflect.Type) bool {
+	return t == reflect.TypeOf(time.Time{}) || t == reflect.TypeOf(&time.Time{})
+}
+
+// Returns now as a value of field's type (see timestampField)
+func timestampValue(field modelField, now time.Time) reflect.Value {
+	t := field.field.Type
+	switch {
+	case t == reflect.TypeOf(time.Time{}):
+		return reflect.ValueOf(now)
+	case t == reflect.TypeOf(&time.Time{}):
+		return reflect.ValueOf(&now)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return reflect.ValueOf(now.Unix()).Convert(t)
+	default:
+		log.Panicf("Timestamp field %v is %v but should be time.Time, *time.Time or an integer!", field.name, t)
+		return reflect.Value{}
+	}
+}
+
+// Sets the timestamp fields of v_model, a model about to be inserted, that
+// are still zero: both CreatedAt and UpdatedAt start at the current time
+func (db *DB) stampCreate(v_model reflect.Value, fields []modelField) {
+	now := db.currentTime()
+	for _, field_of := range []func([]modelField) (modelField, bool){createdAtField, updatedAtField} {
+		if field, ok := field_of(fields); ok {
+			if dst := v_model.FieldByIndex(field.index); dst.IsZero() {
+				dst.Set(timestampValue(field, now))
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package sdorm
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Stamped through its CreatedAt and UpdatedAt fields
+type Article struct {
+	ID        int64 `dorm:"primary_key"`
+	Title     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Stamped through its tagged fields
+type Event struct {
+	ID       int64 `dorm:"primary_key"`
+	Name     string
+	Opened   int64      `dorm:"autocreatetime"`
+	Modified *time.Time `dorm:"autoupdatetime"`
+}
+
+// Tags a field that cannot hold a time
+type Memo struct {
+	ID      int64  `dorm:"primary_key"`
+	Written string `dorm:"autocreatetime"`
+}
+
+// Has an ID field, but no primary key
+type Draft struct {
+	ID    int64
+	Title string
+}
+
+func TestTimestamps(t *testing.T) {
+	fmt.Println(">>> TIMESTAMP TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Article{}, &Event{})
+
+	now := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	db.SetNowFunc(func() time.Time { return now })
+	created := now
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Create Sets Both")
+	article := Article{Title: "Hello"}
+	db.Create(&article)
+	if !article.CreatedAt.Equal(created) || !article.UpdatedAt.Equal(created) {
+		t.Errorf("Expected both timestamps to be %v but instead found %+v", created, article)
+	}
+
+	fmt.Println("Test: Create Keeps Given Times")
+	earlier := now.Add(-time.Hour)
+	imported := Article{Title: "Imported", CreatedAt: earlier}
+	db.Create(&imported)
+	if !imported.CreatedAt.Equal(earlier) || !imported.UpdatedAt.Equal(created) {
+		t.Errorf("Expected CreatedAt %v and UpdatedAt %v but instead found %+v", earlier, created, imported)
+	}
+
+	fmt.Println("Test: Update Bumps UpdatedAt")
+	now = now.Add(time.Minute)
+	updates := make(Updates)
+	addUpdate(updates, "Title", "Hello, World")
+	db.Update(&Article{}, DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": article.ID}}}, updates)
+	found := Article{}
+	db.First(&found, FindArgs{})
+	if found.Title != "Hello, World" || !found.CreatedAt.Equal(created) || !found.UpdatedAt.Equal(now) {
+		t.Errorf("Expected UpdatedAt %v but instead found %+v", now, found)
+	}
+
+	fmt.Println("Test: Update Keeps UpdatedAt Set Explicitly")
+	updates = make(Updates)
+	addUpdate(updates, "UpdatedAt", earlier)
+	db.AllowGlobal().Update(&Article{}, DeleteOrUpdateArgs{}, updates)
+	db.First(&found, FindArgs{})
+	if !found.UpdatedAt.Equal(earlier) {
+		t.Errorf("Expected UpdatedAt %v but instead found %+v", earlier, found)
+	}
+
+	fmt.Println("Test: Save Bumps UpdatedAt Only")
+	now = now.Add(time.Minute)
+	found.Title = "Saved"
+	found.CreatedAt = time.Time{}
+	if err := db.Save(&found); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	if found.Title != "Saved" || !found.CreatedAt.Equal(created) || !found.UpdatedAt.Equal(now) {
+		t.Errorf("Expected CreatedAt %v and UpdatedAt %v but instead found %+v", created, now, found)
+	}
+
+	fmt.Println("Test: Tagged Fields")
+	event := Event{Name: "launch"}
+	db.Create(&event)
+	if event.Opened != now.Unix() || event.Modified == nil || !event.Modified.Equal(now) {
+		t.Errorf("Expected timestamps of %v but instead found %+v", now, event)
+	}
+
+	fmt.Println("Test: Tagged Field of Another Type")
+	db.AutoMigrate(&Memo{})
+	helperTestPanic(t, func() { db.Create(&Memo{}) })
+}
+
+func TestSave(t *testing.T) {
+	fmt.Println(">>> SAVE TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Article{})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Save Inserts New Model")
+	article := Article{Title: "First"}
+	if err := db.Save(&article); err != nil || article.ID == 0 {
+		t.Errorf("Expected an inserted article but instead found %+v (error %v)", article, err)
+	}
+
+	fmt.Println("Test: Save Updates Existing Row")
+	article.Title = "First, Edited"
+	db.Save(&article)
+	articles := []Article{}
+	db.Find(&articles, FindArgs{})
+	if len(articles) != 1 || articles[0].Title != "First, Edited" {
+		t.Errorf("Expected [First, Edited] but instead found %+v", articles)
+	}
+
+	fmt.Println("Test: Save Inserts Missing Key")
+	db.Save(&Article{ID: 10, Title: "Tenth"})
+	count, _ := db.Count(&Article{}, FindArgs{andFilter: Filter{"ID": FilterArg{"eq": 10}}})
+	helperTestIntEquality(t, count, 1)
+
+	fmt.Println("Test: Save Errors")
+	if err := db.Save(Article{}); err == nil {
+		t.Errorf("Expected an error for a non-pointer but got none")
+	}
+	if err := db.Save(&User{}); err == nil {
+		t.Errorf("Expected an error for a model without primary key but got none")
+	}
+	db.AutoMigrate(&Draft{})
+	if err := db.Save(&Draft{Title: "Untitled"}); err == nil {
+		t.Errorf("Expected an error for an untagged ID field but got none")
+	}
+}
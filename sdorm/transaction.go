@@ -0,0 +1,59 @@
+package sdorm
+
+import "database/sql"
+
+// Runs statements against a database connection or, inside Transaction,
+// against a transaction
+type executor interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+/*
+	Transaction runs fn inside a database transaction. fn is passed a DB
+	that runs every statement in the transaction, and that is passed on
+	to the hooks its methods call (see BeforeCreateHook).
+
+	The transaction is committed if fn returns nil, and rolled back if fn
+	returns an error, which Transaction returns, or panics, in which case
+	the panic continues. Calling Transaction on the DB passed to fn runs
+	the inner fn as part of the outer transaction.
+
+	Example usage:
+	err := db.Transaction(func(tx *DB) error {
+		tx.Create(&order)
+		if _, err := tx.Exec("UPDATE stock SET count = count - 1 WHERE item = ?", order.Item); err != nil {
+			return err
+		}
+		return nil
+	})
+*/
+func (db *DB) Transaction(fn func(tx *DB) error) error {
+	if _, ok := db.inner.(*sql.Tx); ok {
+		return fn(db)
+	}
+
+	sql_tx, err := db.conn.Begin()
+	if err != nil {
+		return err
+	}
+	tx := *db
+	tx.inner = sql_tx
+
+	defer func() {
+		if r := recover(); r != nil {
+			sql_tx.Rollback()
+			db.snapshots.clear()
+			db.tables.clear()
+			panic(r)
+		}
+	}()
+	if err := fn(&tx); err != nil {
+		// snapshots and cached tables may record writes that are rolled back
+		sql_tx.Rollback()
+		db.snapshots.clear()
+		db.tables.clear()
+		return err
+	}
+	return sql_tx.Commit()
+}
@@ -0,0 +1,166 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+/*
+	Select returns a copy of db that writes only the given fields of a
+	model in Updates and Save, including fields whose new value is zero.
+	db itself is unchanged.
+
+	Example usage:
+	changes := User{Age: 0}
+	err := db.Select("Age").Updates(&user, changes)
+*/
+func (db *DB) Select(fields ...string) *DB {
+	selected := *db
+	selected.selects = append(append([]string{}, db.selects...), fields...)
+	return &selected
+}
+
+/*
+	Omit returns a copy of db that never writes the given fields of a
+	model in Updates and Save. db itself is unchanged.
+
+	Example usage:
+	err := db.Omit("ClassYear").Save(&user)
+*/
+func (db *DB) Omit(fields ...string) *DB {
+	omitted := *db
+	omitted.omits = append(append([]string{}, db.omits...), fields...)
+	return &omitted
+}
+
+// Returns whether Select and Omit let db write the field with the given name
+func (db *DB) writesField(name string) bool {
+	if len(db.selects) > 0 && !containsString(db.selects, name) {
+		return false
+	}
+	return !containsString(db.omits, name)
+}
+
+// Checks that the fields given to Select and Omit are fields of the
+// model of table, returning an UnknownFieldError if one is not
+func (db *DB) checkWriteFields(table string, fields []modelField) error {
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.name
+	}
+	for _, name := range db.selects {
+		if !containsString(names, name) {
+			return &UnknownFieldError{Field: name, Clause: "select", Table: table, Suggestion: closestName(name, names)}
+		}
+	}
+	for _, name := range db.omits {
+		if !containsString(names, name) {
+			return &UnknownFieldError{Field: name, Clause: "omit", Table: table, Suggestion: closestName(name, names)}
+		}
+	}
+	return nil
+}
+
+/*
+	Updates writes the non-zero fields of changes, a model struct (or a
+	pointer to one) of the same type as model, to the row with the primary
+	key of model, a pointer to a model struct, and copies them into model.
+	It saves building an Updates map whose keys repeat the field names.
+
+	Zero fields are skipped, as changes cannot tell them from fields left
+	unset; Select writes the given fields whether zero or not, and Omit
+	skips the given fields. The primary key is never written, nor is the
+	version field unless selected.
+
+	Updates runs as Update with a filter on the primary key, so hooks,
+	validation, value conversion, the UpdatedAt field and version locking
+	apply as they do there. The UpdatedAt field is also set in model.
+
+	Updates returns an error if model has no primary key or it is zero,
+	if changes is of another type, if Select or Omit name a field the
+	model does not have (an UnknownFieldError), ErrRecordNotFound if no
+	row has the key, or the error Update would panic with.
+
+	Example usage:
+	user := User{}
+	db.First(&user, FindArgs{})
+	err := db.Updates(&user, User{ClassYear: "Senior", Age: 21})
+*/
+func (db *DB) Updates(model interface{}, changes interface{}) (err error) {
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("sdorm: Updates requires a pointer to a struct, not %T", model)
+	}
+	v_model := v.Elem()
+	v_changes := reflect.Indirect(reflect.ValueOf(changes))
+	if !v_changes.IsValid() || v_changes.Type() != v_model.Type() {
+		return fmt.Errorf("sdorm: Updates requires changes of type %v, not %T", v_model.Type(), changes)
+	}
+	model_fields := db.modelFields(v_model.Type())
+	pk, ok := primaryKeyField(model_fields)
+	if !ok || v_model.FieldByIndex(pk.index).IsZero() {
+		return fmt.Errorf("sdorm: Updates requires a model with a primary key value")
+	}
+	defer recoverError(&err)
+	tablename := db.checkTableExists(model)
+	if err := db.checkWriteFields(tablename, model_fields); err != nil {
+		return err
+	}
+
+	// the version is left to version locking unless selected
+	version, _ := versionField(model_fields)
+	update := make(Updates)
+	for _, field := range model_fields {
+		value := v_changes.FieldByIndex(field.index)
+		if field.name == pk.name || !db.writesField(field.name) {
+			continue
+		}
+		if len(db.selects) == 0 && (value.IsZero() || field.name == version.name) {
+			continue
+		}
+		update[field.name] = value.Interface()
+	}
+	if len(update) == 0 {
+		return nil
+	}
+	// set the time rows were last written here, so that model gets it too
+	if updated_at, ok := updatedAtField(model_fields); ok {
+		if _, ok := update[updated_at.name]; !ok {
+			update[updated_at.name] = timestampValue(updated_at, db.currentTime()).Interface()
+		}
+	}
+
+	filter := make(Filter)
+	addFilter(filter, pk.name, "eq", v_model.FieldByIndex(pk.index).Interface())
+	if db.Update(model, DeleteOrUpdateArgs{andFilter: filter}, update) == 0 {
+		return ErrRecordNotFound
+	}
+
+	for name, value := range update {
+		field, _ := findModelField(model_fields, name)
+		new_value := reflect.ValueOf(value)
+		if !new_value.IsValid() {
+			// a nil interface field
+			new_value = reflect.Zero(field.field.Type)
+		}
+		v_model.FieldByIndex(field.index).Set(new_value)
+	}
+	return nil
+}
+
+// Recovers from a panic of the legacy methods, such as Update, storing
+// the error it carries in err. Panics that carry no error are re-raised.
+func recoverError(err *error) {
+	r := recover()
+	switch r := r.(type) {
+	case nil:
+	case error:
+		*err = r
+	case string:
+		// log.Panic panics with its message
+		*err = errors.New(r)
+	default:
+		panic(r)
+	}
+}
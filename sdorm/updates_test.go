@@ -0,0 +1,200 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// Written field by field through Updates and Save
+type Player struct {
+	ID        int64 `dorm:"primary_key"`
+	Name      string
+	Team      string
+	Score     int
+	UpdatedAt time.Time
+}
+
+func TestUpdatesFromStruct(t *testing.T) {
+	fmt.Println(">>> UPDATES TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Player{})
+
+	now := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	db.SetNowFunc(func() time.Time { return now })
+
+	player := Player{Name: "Ada", Team: "Red", Score: 10}
+	db.Create(&player)
+	other := Player{Name: "Bea", Team: "Blue", Score: 20}
+	db.Create(&other)
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Non-Zero Fields")
+	now = now.Add(time.Minute)
+	if err := db.Updates(&player, Player{Team: "Green", Score: 15}); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	expected := Player{ID: player.ID, Name: "Ada", Team: "Green", Score: 15, UpdatedAt: now}
+	if player != expected {
+		t.Errorf("Expected model %+v but instead found %+v", expected, player)
+	}
+	found := Player{}
+	db.First(&found, FindArgs{})
+	if found != expected {
+		t.Errorf("Expected row %+v but instead found %+v", expected, found)
+	}
+	db.Last(&found, FindArgs{})
+	if found.Team != "Blue" || found.Score != 20 {
+		t.Errorf("Expected other rows to be unchanged but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Select Writes Zero Values")
+	if err := db.Select("Score").Updates(&player, &Player{Team: "Ignored"}); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	if player.Score != 0 || found.Score != 0 || found.Team != "Green" {
+		t.Errorf("Expected only Score to be zeroed but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Omit")
+	if err := db.Omit("Team").Updates(&player, Player{Name: "Ann", Team: "Ignored"}); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	if found.Name != "Ann" || found.Team != "Green" {
+		t.Errorf("Expected only Name to change but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Errors")
+	if err := db.Updates(&Player{}, Player{Name: "Nobody"}); err == nil {
+		t.Errorf("Expected an error for a zero primary key but got none")
+	}
+	if err := db.Updates(&player, User{FullName: "Nobody"}); err == nil {
+		t.Errorf("Expected an error for changes of another type but got none")
+	}
+	missing := Player{ID: 99}
+	if err := db.Updates(&missing, Player{Name: "Nobody"}); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Expected %v but instead got %v", ErrRecordNotFound, err)
+	}
+	var unknown *UnknownFieldError
+	if err := db.Select("Scor").Updates(&player, Player{}); !errors.As(err, &unknown) || unknown.Suggestion != "Score" {
+		t.Errorf("Expected an UnknownFieldError suggesting Score but instead got %v", err)
+	}
+}
+
+func TestSaveChangedFields(t *testing.T) {
+	fmt.Println(">>> SAVE CHANGED FIELDS TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Player{})
+	db.SetTrackChanges(true)
+	// writes through another DB leave db's snapshots in place
+	other := NewDB(conn)
+
+	now := time.Date(2020, 3, 14, 15, 9, 26, 0, time.UTC)
+	db.SetNowFunc(func() time.Time { return now })
+	db.Create(&Player{Name: "Ada", Team: "Red", Score: 10})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Only Changed Columns Written")
+	player := Player{}
+	db.First(&player, FindArgs{})
+	// a concurrent write to a column the model does not change
+	if _, err := other.Exec("UPDATE player SET team = ?", "Blue"); err != nil {
+		panic(err)
+	}
+	now = now.Add(time.Minute)
+	player.Score = 11
+	if err := db.Save(&player); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	found := Player{}
+	db.First(&found, FindArgs{})
+	expected := Player{ID: player.ID, Name: "Ada", Team: "Blue", Score: 11, UpdatedAt: now}
+	if found != expected {
+		t.Errorf("Expected %+v but instead found %+v", expected, found)
+	}
+
+	fmt.Println("Test: Unchanged Model Not Written")
+	before := now
+	now = now.Add(time.Minute)
+	if err := db.Save(&found); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	if !found.UpdatedAt.Equal(before) {
+		t.Errorf("Expected UpdatedAt to stay %v but instead found %v", before, found.UpdatedAt)
+	}
+
+	fmt.Println("Test: Changes Since Last Save")
+	found.Name = "Ann"
+	if err := db.Save(&found); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	if _, err := other.Exec("UPDATE player SET name = ?", "Eve"); err != nil {
+		panic(err)
+	}
+	found.Score = 12
+	if err := db.Save(&found); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&player, FindArgs{})
+	if player.Name != "Eve" || player.Score != 12 {
+		t.Errorf("Expected name Eve and score 12 but instead found %+v", player)
+	}
+
+	fmt.Println("Test: Untracked Model Written In Full")
+	untracked := Player{ID: player.ID, Name: "Zed", Team: "Gold", Score: 1}
+	if err := other.Save(&untracked); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&player, FindArgs{})
+	if player.Name != "Zed" || player.Team != "Gold" || player.Score != 1 {
+		t.Errorf("Expected every column to be written but instead found %+v", player)
+	}
+
+	fmt.Println("Test: Omit in Save")
+	player.Team = "Ignored"
+	player.Score = 2
+	if err := db.Omit("Team").Save(&player); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	if found.Team != "Gold" || found.Score != 2 {
+		t.Errorf("Expected only Score to be written but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Writes Through db Drop Snapshots")
+	db.First(&player, FindArgs{})
+	updates := make(Updates)
+	addUpdate(updates, "Score", 30)
+	db.Update(&Player{}, DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": player.ID}}}, updates)
+	if err := db.Save(&player); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&found, FindArgs{})
+	helperTestIntEquality(t, found.Score, player.Score)
+
+	if _, err := db.Exec("UPDATE player SET score = ?", 40); err != nil {
+		panic(err)
+	}
+	if err := db.Save(&found); err != nil {
+		t.Errorf("Expected no error but instead got %v", err)
+	}
+	db.First(&player, FindArgs{})
+	helperTestIntEquality(t, player.Score, found.Score)
+
+	fmt.Println("Test: Untracked by Default")
+	db.SetTrackChanges(false)
+	db.First(&player, FindArgs{})
+	if _, ok := db.snapshots.get("player", snapshotKey(player.ID)); ok {
+		t.Errorf("Expected no snapshot without change tracking")
+	}
+}
@@ -0,0 +1,214 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+/*
+	Type of the error returned by Save, and panicked by Create and Update,
+	when fields fail their validation rules, with one FieldError per
+	failing field and rule.
+
+	Rules are listed in a field's tag, separated by commas:
+	- required: the value is not the zero value (or a nil pointer)
+	- min=N, max=N: the number is at least, or at most, N
+	- len=N, len<=N, len>=N, len<N, len>N: the length of the string
+	  (in characters), slice or map compares to N
+	- regex=EXPR: the string matches the regular expression EXPR, which
+	  runs to the end of the tag and may contain commas but not semicolons
+
+	Rules other than required are skipped for nil pointers. Create and
+	Save check every field of the model, and Update the fields it sets.
+	A malformed rule causes a panic.
+
+	Example usage:
+	type Person struct {
+		Name  string `dorm:"validate:required,len<=64"`
+		Age   int    `dorm:"validate:min=0,max=150"`
+		Email string `dorm:"validate:regex=^[^@]+@[^@]+$"`
+	}
+	var errs ValidationErrors
+	if err := db.Save(&person); errors.As(err, &errs) {
+		for _, field_err := range errs {
+			fmt.Println(field_err.Field, field_err.Rule)
+		}
+	}
+*/
+type ValidationErrors []FieldError
+
+// A field that failed one of its validation rules
+type FieldError struct {
+	Field string
+	Rule  string
+	Value interface{}
+}
+
+func (err FieldError) Error() string {
+	return fmt.Sprintf("%v fails %v (value %#v)", err.Field, err.Rule, err.Value)
+}
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return "sdorm: validation failed: " + strings.Join(messages, "; ")
+}
+
+// Checks every field of the model v_model against its validation rules,
+// returning ValidationErrors if any fail
+func validateModel(v_model reflect.Value, fields []modelField) error {
+	errs := ValidationErrors{}
+	for _, field := range fields {
+		errs = append(errs, validateField(field, v_model.FieldByIndex(field.index))...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Checks the new values of the fields in update against their validation
+// rules, returning ValidationErrors if any fail
+func validateUpdates(fields []modelField, update Updates) error {
+	names := make([]string, 0, len(update))
+	for name := range update {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	errs := ValidationErrors{}
+	for _, name := range names {
+		if field, ok := findModelField(fields, name); ok && update[name] != nil {
+			errs = append(errs, validateField(field, reflect.ValueOf(update[name]))...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Checks value against the validation rules of field
+func validateField(field modelField, value reflect.Value) ValidationErrors {
+	errs := ValidationErrors{}
+	for _, rule := range validationRules(field) {
+		if !checkRule(field, rule, value) {
+			errs = append(errs, FieldError{Field: field.name, Rule: rule, Value: value.Interface()})
+		}
+	}
+	return errs
+}
+
+// Returns the validation rules in the tag of field
+func validationRules(field modelField) []string {
+	tag, ok := field.settings["validate"]
+	if !ok {
+		return nil
+	}
+	rules := []string{}
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			// the expression runs to the end of the tag
+			return append(rules, tag)
+		}
+		parts := strings.SplitN(tag, ",", 2)
+		if rule := strings.TrimSpace(parts[0]); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = ""
+		if len(parts) == 2 {
+			tag = strings.TrimSpace(parts[1])
+		}
+	}
+	return rules
+}
+
+// Checks if value passes rule, panicking if rule is malformed
+func checkRule(field modelField, rule string, value reflect.Value) bool {
+	if rule == "required" {
+		return !value.IsZero()
+	}
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+
+	switch {
+	case strings.HasPrefix(rule, "regex="):
+		expr, err := regexp.Compile(strings.TrimPrefix(rule, "regex="))
+		if err != nil || value.Kind() != reflect.String {
+			log.Panicf("Invalid validation rule %v on field %v!", rule, field.name)
+		}
+		return expr.MatchString(value.String())
+	case strings.HasPrefix(rule, "min="), strings.HasPrefix(rule, "max="):
+		bound, err := strconv.ParseFloat(rule[len("min="):], 64)
+		number, ok := numberValue(value)
+		if err != nil || !ok {
+			log.Panicf("Invalid validation rule %v on field %v!", rule, field.name)
+		}
+		if strings.HasPrefix(rule, "min=") {
+			return number >= bound
+		}
+		return number <= bound
+	case strings.HasPrefix(rule, "len"):
+		for _, operator := range []string{"<=", ">=", "=", "<", ">"} {
+			if !strings.HasPrefix(rule, "len"+operator) {
+				continue
+			}
+			bound, err := strconv.Atoi(rule[len("len"+operator):])
+			length, ok := lengthValue(value)
+			if err != nil || !ok {
+				break
+			}
+			switch operator {
+			case "<=":
+				return length <= bound
+			case ">=":
+				return length >= bound
+			case "=":
+				return length == bound
+			case "<":
+				return length < bound
+			default:
+				return length > bound
+			}
+		}
+	}
+	log.Panicf("Invalid validation rule %v on field %v!", rule, field.name)
+	return false
+}
+
+// Returns value as a float64, or false if it is not a number
+func numberValue(value reflect.Value) (float64, bool) {
+	switch {
+	case value.Kind() >= reflect.Int && value.Kind() <= reflect.Int64:
+		return float64(value.Int()), true
+	case value.Kind() >= reflect.Uint && value.Kind() <= reflect.Uintptr:
+		return float64(value.Uint()), true
+	case value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// Returns the length of value, counting characters for strings, or
+// false if it has no length
+func lengthValue(value reflect.Value) (int, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return utf8.RuneCountInString(value.String()), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return value.Len(), true
+	}
+	return 0, false
+}
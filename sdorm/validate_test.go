@@ -0,0 +1,107 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Model whose fields carry validation rules
+type Signup struct {
+	ID       int64    `dorm:"primary_key"`
+	Name     string   `dorm:"validate:required,len<=8"`
+	Age      int      `dorm:"validate:min=0,max=150"`
+	Email    string   `dorm:"validate:regex=^[a-z]+@[a-z]+\\.(com|org)$"`
+	Nickname *string  `dorm:"validate:len>=2"`
+	Tags     []string `dorm:"json;validate:len<3"`
+}
+
+// Returns the "Field rule" pairs of the validation error err
+func helperValidationRules(t *testing.T, err error) []string {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Errorf("Expected ValidationErrors but instead got %v", err)
+		return nil
+	}
+	rules := []string{}
+	for _, field_err := range errs {
+		rules = append(rules, field_err.Field+" "+field_err.Rule)
+	}
+	return rules
+}
+
+func TestValidation(t *testing.T) {
+	fmt.Println(">>> VALIDATION TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Signup{})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Valid Model")
+	nickname := "Nicky"
+	valid := Signup{Name: "Nick", Age: 20, Email: "nick@example.com", Nickname: &nickname, Tags: []string{"a", "b"}}
+	db.Create(&valid)
+	count, _ := db.Count(&Signup{}, FindArgs{})
+	helperTestIntEquality(t, count, 1)
+
+	fmt.Println("Test: Every Failing Rule Listed")
+	short_nickname := "N"
+	invalid := Signup{Name: "Bartholomew", Age: 200, Email: "not an email", Nickname: &short_nickname, Tags: []string{"a", "b", "c"}}
+	err := db.Save(&invalid)
+	expected := []string{"Name len<=8", "Age max=150", "Email regex=^[a-z]+@[a-z]+\\.(com|org)$", "Nickname len>=2", "Tags len<3"}
+	if rules := helperValidationRules(t, err); fmt.Sprint(rules) != fmt.Sprint(expected) {
+		t.Errorf("Expected failing rules %v but instead found %v", expected, rules)
+	}
+
+	fmt.Println("Test: Create Panics With Required and Min")
+	func() {
+		defer func() {
+			rules := helperValidationRules(t, recover().(error))
+			if fmt.Sprint(rules) != fmt.Sprint([]string{"Name required", "Age min=0", "Email regex=^[a-z]+@[a-z]+\\.(com|org)$"}) {
+				t.Errorf("Expected Name, Age and Email to fail but instead found %v", rules)
+			}
+		}()
+		db.Create(&Signup{Age: -1})
+	}()
+	count, _ = db.Count(&Signup{}, FindArgs{})
+	helperTestIntEquality(t, count, 1)
+
+	fmt.Println("Test: Save of Existing Row Validated")
+	valid.Age = 151
+	if rules := helperValidationRules(t, db.Save(&valid)); fmt.Sprint(rules) != "[Age max=150]" {
+		t.Errorf("Expected [Age max=150] but instead found %v", rules)
+	}
+
+	fmt.Println("Test: Update Validates New Values Only")
+	updates := make(Updates)
+	addUpdate(updates, "Age", 30)
+	rows_updated := db.AllowGlobal().Update(&Signup{}, DeleteOrUpdateArgs{}, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+	func() {
+		defer func() {
+			if rules := helperValidationRules(t, recover().(error)); fmt.Sprint(rules) != "[Name required]" {
+				t.Errorf("Expected [Name required] but instead found %v", rules)
+			}
+		}()
+		updates = make(Updates)
+		addUpdate(updates, "Name", "")
+		db.AllowGlobal().Update(&Signup{}, DeleteOrUpdateArgs{}, updates)
+	}()
+	found := Signup{}
+	db.First(&found, FindArgs{})
+	if found.Name != "Nick" || found.Age != 30 {
+		t.Errorf("Expected Nick aged 30 but instead found %+v", found)
+	}
+
+	helperTestPanic(t, func() {
+		fmt.Println("Test: Malformed Rule")
+		type BadRule struct {
+			Name string `dorm:"validate:min=3"`
+		}
+		bad := BadRule{Name: "x"}
+		validateModel(reflect.ValueOf(bad), modelFields(reflect.TypeOf(bad), SnakeCaseNaming{}))
+	})
+}
@@ -0,0 +1,89 @@
+package sdorm
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+)
+
+/*
+	Returns the field tagged `dorm:"version"`, or false if the model has none.
+
+	Models with an integer field tagged `dorm:"version"` are locked
+	optimistically: Save and Update only write rows whose version column
+	still equals the model's version field, increment the column on every
+	write, and advance the model's field to match. If another writer has
+	changed a row since the model was read, nothing is written and the
+	write fails with ErrStaleObject (returned by Save, panicked by Update).
+	Update only locks the row of a model with a non-zero primary key; a
+	bulk update through an empty model increments versions unchecked.
+
+	Example usage with two writers editing the same row:
+	type Page struct {
+		ID      int64 `dorm:"primary_key"`
+		Body    string
+		Version int   `dorm:"version"`
+	}
+	mine, theirs := Page{}, Page{}
+	db.First(&mine, FindArgs{})
+	db.First(&theirs, FindArgs{})
+	theirs.Body = "theirs"
+	db.Save(&theirs) // succeeds, the row is now at version 1
+	mine.Body = "mine"
+	err := db.Save(&mine) // ErrStaleObject, mine is still at version 0
+*/
+func versionField(fields []modelField) (modelField, bool) {
+	for _, field := range fields {
+		if _, ok := field.settings["version"]; ok {
+			if kind := field.field.Type.Kind(); kind < reflect.Int || kind > reflect.Uint64 {
+				log.Panicf("Version field %v is %v but should be an integer!", field.name, field.field.Type)
+			}
+			return field, true
+		}
+	}
+	return modelField{}, false
+}
+
+// Returns whether v_model, the value a model pointer passed to Update
+// points to, is a model struct with a non-zero primary key, so that
+// Update locks its row at its version
+func identifiesRow(v_model reflect.Value, fields []modelField) bool {
+	if v_model.Kind() != reflect.Struct {
+		return false
+	}
+	pk, ok := primaryKeyField(fields)
+	return ok && !v_model.FieldByIndex(pk.index).IsZero()
+}
+
+// Returns the SET clause incrementing the version column of version
+func versionIncrement(version modelField) string {
+	return fmt.Sprintf("%v=%v+1", version.column, version.column)
+}
+
+/*
+	Finishes a versioned write to the rows of tablename matching filter
+	that were still at the version of v_model, which affected rows_affected
+	rows: advances the version of v_model if rows were written, and returns
+	ErrStaleObject if none were but some rows match filter at another version.
+*/
+func (db *DB) finishVersionedWrite(tablename string, v_model reflect.Value, version modelField, filter Filter, fields []modelField, rows_affected int) error {
+	if rows_affected > 0 {
+		dst := v_model.FieldByIndex(version.index)
+		if dst.Kind() >= reflect.Uint && dst.Kind() <= reflect.Uint64 {
+			dst.SetUint(dst.Uint() + 1)
+		} else {
+			dst.SetInt(dst.Int() + 1)
+		}
+		return nil
+	}
+
+	where_string, where_args := db.buildWhereString(filter, fields)
+	count := 0
+	if err := db.Raw(&count, "SELECT COUNT(*) FROM "+tablename+where_string, where_args...); err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrStaleObject
+	}
+	return nil
+}
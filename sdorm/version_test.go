@@ -0,0 +1,111 @@
+package sdorm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// Locked optimistically through its Version field
+type Page struct {
+	ID      int64 `dorm:"primary_key"`
+	Body    string
+	Version int `dorm:"version"`
+}
+
+// Locked through an unsigned Version field
+type Revision struct {
+	ID      int64 `dorm:"primary_key"`
+	Body    string
+	Version uint `dorm:"version"`
+}
+
+// Tags a field that cannot count versions
+type Label struct {
+	ID      int64  `dorm:"primary_key"`
+	Version string `dorm:"version"`
+}
+
+func TestOptimisticLocking(t *testing.T) {
+	fmt.Println(">>> OPTIMISTIC LOCKING TESTS <<<")
+	conn := connectSQL()
+	db := NewDB(conn)
+	defer db.Close()
+	db.AutoMigrate(&Page{})
+	db.Create(&Page{Body: "draft"})
+
+	/* ------------------------------------------------------------ */
+
+	fmt.Println("Test: Competing Saves")
+	mine, theirs := Page{}, Page{}
+	db.First(&mine, FindArgs{})
+	db.First(&theirs, FindArgs{})
+	theirs.Body = "theirs"
+	if err := db.Save(&theirs); err != nil || theirs.Version != 1 {
+		t.Errorf("Expected save at version 1 but instead found %+v (error %v)", theirs, err)
+	}
+	mine.Body = "mine"
+	if err := db.Save(&mine); !errors.Is(err, ErrStaleObject) || mine.Version != 0 {
+		t.Errorf("Expected ErrStaleObject at version 0 but instead found %+v (error %v)", mine, err)
+	}
+	found := Page{}
+	db.First(&found, FindArgs{})
+	if found.Body != "theirs" || found.Version != 1 {
+		t.Errorf("Expected theirs at version 1 but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Save After Reload")
+	db.First(&mine, FindArgs{})
+	mine.Body = "mine"
+	if err := db.Save(&mine); err != nil || mine.Version != 2 {
+		t.Errorf("Expected save at version 2 but instead found %+v (error %v)", mine, err)
+	}
+
+	fmt.Println("Test: Competing Updates")
+	db.First(&mine, FindArgs{})
+	db.First(&theirs, FindArgs{})
+	args := DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": mine.ID}}}
+	updates := make(Updates)
+	addUpdate(updates, "Body", "theirs again")
+	rows_updated := db.Update(&theirs, args, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+	helperTestIntEquality(t, theirs.Version, 3)
+	helperTestPanicError(t, ErrStaleObject, func() {
+		updates = make(Updates)
+		addUpdate(updates, "Body", "mine again")
+		db.Update(&mine, args, updates)
+	})
+	db.First(&found, FindArgs{})
+	if found.Body != "theirs again" || found.Version != 3 {
+		t.Errorf("Expected theirs again at version 3 but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Update Matching No Rows Is Not Stale")
+	rows_updated = db.Update(&found, DeleteOrUpdateArgs{andFilter: Filter{"ID": FilterArg{"eq": 100}}}, updates)
+	helperTestIntEquality(t, rows_updated, 0)
+
+	fmt.Println("Test: Bulk Update Through an Empty Model")
+	updates = make(Updates)
+	addUpdate(updates, "Body", "bulk")
+	rows_updated = db.Update(&Page{}, args, updates)
+	helperTestIntEquality(t, rows_updated, 1)
+	db.First(&found, FindArgs{})
+	if found.Body != "bulk" || found.Version != 4 {
+		t.Errorf("Expected bulk at version 4 but instead found %+v", found)
+	}
+
+	fmt.Println("Test: Unsigned Version")
+	db.AutoMigrate(&Revision{})
+	revision := Revision{Body: "draft"}
+	db.Create(&revision)
+	revision.Body = "final"
+	if err := db.Save(&revision); err != nil || revision.Version != 1 {
+		t.Errorf("Expected save at version 1 but instead found %+v (error %v)", revision, err)
+	}
+
+	fmt.Println("Test: Version Field of Another Type")
+	db.AutoMigrate(&Label{})
+	helperTestPanic(t, func() {
+		db.Update(&Label{ID: 1}, args, updates)
+	})
+}